@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -11,6 +12,132 @@ type (
 	Config struct {
 		Db     *Database
 		Server *Server
+		Worker *Worker
+		Internal *Internal
+		Mail     *Mail
+		Push     *Push
+		Storage  *Storage
+		Media    Media
+		UserGraph UserGraph `mapstructure:"user_graph"`
+		Feed      Feed
+		Search    Search
+	}
+
+	// Feed tunes the ranked personal feed. Each candidate post scores
+	// AffinityWeight*ln(1+interactions with its author) +
+	// EngagementWeight*ln(1+likes+comments+saves) +
+	// RecencyWeight*2^(-age/HalfLife). Posts the viewer already saw are kept
+	// in a capped set of SeenCap ids that expires SeenTTL after the last
+	// impression, and the feed is caught up once every post from the last
+	// CaughtUpWindow is in it.
+	Feed struct {
+		Candidates       int
+		Window           time.Duration
+		AffinityWindow   time.Duration `mapstructure:"affinity_window"`
+		HalfLife         time.Duration `mapstructure:"half_life"`
+		SnapshotTTL      time.Duration `mapstructure:"snapshot_ttl"`
+		AffinityWeight   float64       `mapstructure:"affinity_weight"`
+		EngagementWeight float64       `mapstructure:"engagement_weight"`
+		RecencyWeight    float64       `mapstructure:"recency_weight"`
+		SeenCap          int           `mapstructure:"seen_cap"`
+		SeenTTL          time.Duration `mapstructure:"seen_ttl"`
+		CaughtUpWindow   time.Duration `mapstructure:"caught_up_window"`
+	}
+
+	// Search tunes full-text search. Results are ranked by text relevance,
+	// which halves for every HalfLife a result is older, and pages hold
+	// PageSize results unless the client asks for another size.
+	Search struct {
+		HalfLife time.Duration `mapstructure:"half_life"`
+		PageSize int           `mapstructure:"page_size"`
+	}
+
+	UserGraph struct {
+		Transport          string
+		GRPCAddress        string `mapstructure:"grpc_address"`
+		Timeout            time.Duration
+		MaxRetries         int           `mapstructure:"max_retries"`
+		RetryBaseDelay     time.Duration `mapstructure:"retry_base_delay"`
+		RetryMaxDelay      time.Duration `mapstructure:"retry_max_delay"`
+		BreakerFailures    uint32        `mapstructure:"breaker_failures"`
+		BreakerOpenTimeout time.Duration `mapstructure:"breaker_open_timeout"`
+		CacheTTL           time.Duration `mapstructure:"cache_ttl"`
+	}
+
+	// Storage selects where uploaded media lives. Clients fetch media from
+	// PublicBaseURL, the service's media endpoint, with URLs signed by
+	// SigningSecret that expire after one to two SignedURLTTLs.
+	Storage struct {
+		Driver        string
+		LocalDir      string        `mapstructure:"local_dir"`
+		PublicBaseURL string        `mapstructure:"public_base_url"`
+		SigningSecret string        `mapstructure:"signing_secret"`
+		SignedURLTTL  time.Duration `mapstructure:"signed_url_ttl"`
+		S3            S3            `mapstructure:"s3"`
+	}
+
+	// Media limits and shapes uploaded images. Each upload is stored as a
+	// square thumbnail plus feed and full renditions whose long edge is at
+	// most FeedSize and FullSize pixels. Uploads arrive in chunks of at most
+	// MaxChunkBytes, and an upload session expires UploadTTL after it was
+	// last written to or completed. Videos may be up to MaxVideoBytes and
+	// MaxVideoDuration long; they are probed with the ffprobe and ffmpeg
+	// binaries when present, and get a poster frame taken PosterAt in.
+	Media struct {
+		MaxUploadBytes   int64         `mapstructure:"max_upload_bytes"`
+		MaxChunkBytes    int64         `mapstructure:"max_chunk_bytes"`
+		UploadTTL        time.Duration `mapstructure:"upload_ttl"`
+		MaxPixels        int           `mapstructure:"max_pixels"`
+		JPEGQuality      int           `mapstructure:"jpeg_quality"`
+		ThumbnailSize    int           `mapstructure:"thumbnail_size"`
+		FeedSize         int           `mapstructure:"feed_size"`
+		FullSize         int           `mapstructure:"full_size"`
+		MaxVideoBytes    int64         `mapstructure:"max_video_bytes"`
+		MaxVideoDuration time.Duration `mapstructure:"max_video_duration"`
+		FFprobePath      string        `mapstructure:"ffprobe_path"`
+		FFmpegPath       string        `mapstructure:"ffmpeg_path"`
+		ProbeTimeout     time.Duration `mapstructure:"probe_timeout"`
+		PosterAt         time.Duration `mapstructure:"poster_at"`
+	}
+
+	S3 struct {
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string `mapstructure:"access_key"`
+		SecretKey string `mapstructure:"secret_key"`
+		PathStyle bool   `mapstructure:"path_style"`
+		Timeout   time.Duration
+	}
+
+	Push struct {
+		Driver  string
+		Timeout time.Duration
+		FCM     FCM  `mapstructure:"fcm"`
+		APNs    APNs `mapstructure:"apns"`
+	}
+
+	FCM struct {
+		ProjectID   string `mapstructure:"project_id"`
+		AccessToken string `mapstructure:"access_token"`
+	}
+
+	APNs struct {
+		KeyPath    string `mapstructure:"key_path"`
+		KeyID      string `mapstructure:"key_id"`
+		TeamID     string `mapstructure:"team_id"`
+		Topic      string
+		Production bool
+	}
+
+	Mail struct {
+		Driver    string
+		Host      string
+		Port      int
+		Username  string
+		Password  string
+		From      string
+		OutputDir string `mapstructure:"output_dir"`
 	}
 
 	Database struct {
@@ -27,8 +154,331 @@ type (
 		Port int
 		UserGraphBaseURL string `mapstructure:"user_graph_base_url"`
 	}
+
+	Internal struct {
+		MaxClockSkew time.Duration     `mapstructure:"max_clock_skew"`
+		Services     map[string]string `mapstructure:"services"`
+	}
+
+	Worker struct {
+		NotificationFanout    Queue     `mapstructure:"notification_fanout"`
+		NotificationRetention Retention `mapstructure:"notification_retention"`
+		Webhook               Webhook
+		NotificationDigest    Digest `mapstructure:"notification_digest"`
+		NotificationPush      Queue  `mapstructure:"notification_push"`
+		Explore               Explore
+		UploadCleanup         UploadCleanup `mapstructure:"upload_cleanup"`
+	}
+
+	Explore struct {
+		HalfLife      time.Duration `mapstructure:"half_life"`
+		Window        time.Duration
+		Interval      time.Duration
+		Candidates    int
+		RebaseAfter   time.Duration `mapstructure:"rebase_after"`
+		LikeWeight    float64       `mapstructure:"like_weight"`
+		CommentWeight float64       `mapstructure:"comment_weight"`
+		SaveWeight    float64       `mapstructure:"save_weight"`
+		PostWeight    float64       `mapstructure:"post_weight"`
+	}
+
+	// UploadCleanup removes up to BatchSize expired upload sessions, and
+	// whatever they stored, every Interval.
+	UploadCleanup struct {
+		Interval  time.Duration
+		BatchSize int `mapstructure:"batch_size"`
+	}
+
+	Digest struct {
+		Interval  time.Duration
+		BatchSize int `mapstructure:"batch_size"`
+		MaxItems  int `mapstructure:"max_items"`
+	}
+
+	Webhook struct {
+		Workers      int
+		BatchSize    int           `mapstructure:"batch_size"`
+		MaxAttempts  int           `mapstructure:"max_attempts"`
+		BaseBackoff  time.Duration `mapstructure:"base_backoff"`
+		MaxBackoff   time.Duration `mapstructure:"max_backoff"`
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		Timeout      time.Duration
+	}
+
+	Retention struct {
+		MaxAge    time.Duration `mapstructure:"max_age"`
+		Interval  time.Duration
+		BatchSize int `mapstructure:"batch_size"`
+	}
+
+	Queue struct {
+		Workers     int
+		BatchSize   int           `mapstructure:"batch_size"`
+		MaxAttempts int           `mapstructure:"max_attempts"`
+		BaseBackoff time.Duration `mapstructure:"base_backoff"`
+		MaxBackoff  time.Duration `mapstructure:"max_backoff"`
+		ClaimIdle   time.Duration `mapstructure:"claim_idle"`
+		MaxLen      int64         `mapstructure:"max_len"`
+	}
 )
 
+func (q Queue) WithDefaults() Queue {
+	if q.Workers <= 0 {
+		q.Workers = 4
+	}
+	if q.BatchSize <= 0 {
+		q.BatchSize = 10
+	}
+	if q.MaxAttempts <= 0 {
+		q.MaxAttempts = 5
+	}
+	if q.BaseBackoff <= 0 {
+		q.BaseBackoff = 2 * time.Second
+	}
+	if q.MaxBackoff <= 0 {
+		q.MaxBackoff = 5 * time.Minute
+	}
+	if q.ClaimIdle <= 0 {
+		q.ClaimIdle = time.Minute
+	}
+	if q.MaxLen <= 0 {
+		q.MaxLen = 100000
+	}
+	return q
+}
+
+func (r Retention) WithDefaults() Retention {
+	if r.MaxAge <= 0 {
+		r.MaxAge = 90 * 24 * time.Hour
+	}
+	if r.Interval <= 0 {
+		r.Interval = time.Hour
+	}
+	if r.BatchSize <= 0 {
+		r.BatchSize = 1000
+	}
+	return r
+}
+
+func (w Webhook) WithDefaults() Webhook {
+	if w.Workers <= 0 {
+		w.Workers = 4
+	}
+	if w.BatchSize <= 0 {
+		w.BatchSize = 20
+	}
+	if w.MaxAttempts <= 0 {
+		w.MaxAttempts = 8
+	}
+	if w.BaseBackoff <= 0 {
+		w.BaseBackoff = 10 * time.Second
+	}
+	if w.MaxBackoff <= 0 {
+		w.MaxBackoff = time.Hour
+	}
+	if w.PollInterval <= 0 {
+		w.PollInterval = time.Second
+	}
+	if w.Timeout <= 0 {
+		w.Timeout = 10 * time.Second
+	}
+	return w
+}
+
+func (d Digest) WithDefaults() Digest {
+	if d.Interval <= 0 {
+		d.Interval = 15 * time.Minute
+	}
+	if d.BatchSize <= 0 {
+		d.BatchSize = 100
+	}
+	if d.MaxItems <= 0 {
+		d.MaxItems = 20
+	}
+	return d
+}
+
+func (e Explore) WithDefaults() Explore {
+	if e.HalfLife <= 0 {
+		e.HalfLife = 6 * time.Hour
+	}
+	if e.Window <= 0 {
+		e.Window = 7 * 24 * time.Hour
+	}
+	if e.Interval <= 0 {
+		e.Interval = 10 * time.Minute
+	}
+	if e.Candidates <= 0 {
+		e.Candidates = 500
+	}
+	if e.RebaseAfter <= 0 {
+		e.RebaseAfter = 7 * 24 * time.Hour
+	}
+	if e.LikeWeight <= 0 {
+		e.LikeWeight = 1
+	}
+	if e.CommentWeight <= 0 {
+		e.CommentWeight = 2
+	}
+	if e.SaveWeight <= 0 {
+		e.SaveWeight = 3
+	}
+	if e.PostWeight <= 0 {
+		e.PostWeight = 1
+	}
+	return e
+}
+
+func (u UploadCleanup) WithDefaults() UploadCleanup {
+	if u.Interval <= 0 {
+		u.Interval = 15 * time.Minute
+	}
+	if u.BatchSize <= 0 {
+		u.BatchSize = 100
+	}
+	return u
+}
+
+func (f Feed) WithDefaults() Feed {
+	if f.Candidates <= 0 {
+		f.Candidates = 300
+	}
+	if f.Window <= 0 {
+		f.Window = 72 * time.Hour
+	}
+	if f.AffinityWindow <= 0 {
+		f.AffinityWindow = 30 * 24 * time.Hour
+	}
+	if f.HalfLife <= 0 {
+		f.HalfLife = 12 * time.Hour
+	}
+	if f.SnapshotTTL <= 0 {
+		f.SnapshotTTL = 30 * time.Minute
+	}
+	if f.AffinityWeight <= 0 {
+		f.AffinityWeight = 1
+	}
+	if f.EngagementWeight <= 0 {
+		f.EngagementWeight = 0.5
+	}
+	if f.RecencyWeight <= 0 {
+		f.RecencyWeight = 3
+	}
+	if f.SeenCap <= 0 {
+		f.SeenCap = 2000
+	}
+	if f.SeenTTL <= 0 {
+		f.SeenTTL = 7 * 24 * time.Hour
+	}
+	if f.CaughtUpWindow <= 0 {
+		f.CaughtUpWindow = 72 * time.Hour
+	}
+	return f
+}
+
+func (s Search) WithDefaults() Search {
+	if s.HalfLife <= 0 {
+		s.HalfLife = 30 * 24 * time.Hour
+	}
+	if s.PageSize <= 0 {
+		s.PageSize = 20
+	}
+	return s
+}
+
+func (s Storage) WithDefaults() Storage {
+	if s.Driver == "" {
+		s.Driver = "local"
+	}
+	if s.LocalDir == "" {
+		s.LocalDir = "storage"
+	}
+	if s.PublicBaseURL == "" {
+		s.PublicBaseURL = "/v1/media"
+	}
+	if s.SignedURLTTL <= 0 {
+		s.SignedURLTTL = 15 * time.Minute
+	}
+	if s.S3.Region == "" {
+		s.S3.Region = "us-east-1"
+	}
+	if s.S3.Timeout <= 0 {
+		s.S3.Timeout = 30 * time.Second
+	}
+	return s
+}
+
+func (m Media) WithDefaults() Media {
+	if m.MaxUploadBytes <= 0 {
+		m.MaxUploadBytes = 10 << 20
+	}
+	if m.MaxChunkBytes <= 0 {
+		m.MaxChunkBytes = 2 << 20
+	}
+	if m.UploadTTL <= 0 {
+		m.UploadTTL = 24 * time.Hour
+	}
+	if m.MaxPixels <= 0 {
+		m.MaxPixels = 40_000_000
+	}
+	if m.JPEGQuality <= 0 || m.JPEGQuality > 100 {
+		m.JPEGQuality = 85
+	}
+	if m.ThumbnailSize <= 0 {
+		m.ThumbnailSize = 320
+	}
+	if m.FeedSize <= 0 {
+		m.FeedSize = 1080
+	}
+	if m.FullSize <= 0 {
+		m.FullSize = 2048
+	}
+	if m.MaxVideoBytes <= 0 {
+		m.MaxVideoBytes = 100 << 20
+	}
+	if m.MaxVideoDuration <= 0 {
+		m.MaxVideoDuration = 60 * time.Second
+	}
+	if m.FFprobePath == "" {
+		m.FFprobePath = "ffprobe"
+	}
+	if m.FFmpegPath == "" {
+		m.FFmpegPath = "ffmpeg"
+	}
+	if m.ProbeTimeout <= 0 {
+		m.ProbeTimeout = 30 * time.Second
+	}
+	if m.PosterAt <= 0 {
+		m.PosterAt = time.Second
+	}
+	return m
+}
+
+func (u UserGraph) WithDefaults() UserGraph {
+	if u.Timeout <= 0 {
+		u.Timeout = 2 * time.Second
+	}
+	if u.MaxRetries <= 0 {
+		u.MaxRetries = 2
+	}
+	if u.RetryBaseDelay <= 0 {
+		u.RetryBaseDelay = 100 * time.Millisecond
+	}
+	if u.RetryMaxDelay <= 0 {
+		u.RetryMaxDelay = 2 * time.Second
+	}
+	if u.BreakerFailures == 0 {
+		u.BreakerFailures = 5
+	}
+	if u.BreakerOpenTimeout <= 0 {
+		u.BreakerOpenTimeout = 30 * time.Second
+	}
+	if u.CacheTTL <= 0 {
+		u.CacheTTL = time.Minute
+	}
+	return u
+}
+
 var (
 	once   sync.Once
 	config *Config
@@ -2,17 +2,27 @@ package comments
 
 import (
 	"bootcamp-content-interaction-service/domains/comments/entities"
+	"bootcamp-content-interaction-service/domains/comments/models/request"
+	"bootcamp-content-interaction-service/domains/comments/models/response"
+	"bootcamp-content-interaction-service/shared/util"
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrPostNotFound  = errors.New("post not found")
+)
+
 type CommentsUseCase interface {
 	CreateComment(ctx context.Context, userId, postId, msg string, replyId *string) error
 	UpdateComment(ctx context.Context, id, userId, msg string) error
 	ReplyComment(ctx context.Context, id, userId, postId, msg string) error
 	FindAllComment(ctx context.Context, postId string) (*[]entities.Comments,error)
 	DeleteComment(ctx context.Context, id uuid.UUID) (error) 
+	SearchComments(ctx context.Context, request *request.SearchRequest) (*response.CommentSearchPage, error)
 }
 
 type CommentsRepository interface {
@@ -21,4 +31,6 @@ type CommentsRepository interface {
 	ReplyComment(ctx context.Context, id, userId, postId, msg string) error
 	FindAllComment(ctx context.Context, postId string) (*[]entities.Comments, error)
 	DeleteComment(ctx context.Context, id uuid.UUID) (error) 
+	InvalidateCache(ctx context.Context, postId string) error
+	SearchComments(ctx context.Context, search util.TextSearch) ([]entities.Comments, *util.ScoreCursor, error)
 }
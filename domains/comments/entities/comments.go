@@ -16,4 +16,7 @@ type Comments struct {
 	CreatedAt time.Time  	`json:"created_at" gorm:"type:timestamp"`
 	UpdatedAt time.Time  	`json:"updated_at" gorm:"type:timestamp"`
 	Msg       string     	`json:"msg" gorm:"type:string"`
+	// SearchVector indexes Msg for full-text search. Postgres keeps it in
+	// step with Msg, so the service never reads or writes it.
+	SearchVector string  	`json:"-" gorm:"type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(msg, ''))) STORED;index:idx_comments_search_vector,type:gin;->:false;<-:false"`
 }
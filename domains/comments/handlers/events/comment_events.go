@@ -0,0 +1,32 @@
+package events
+
+import (
+	"bootcamp-content-interaction-service/domains/comments"
+	"bootcamp-content-interaction-service/shared/events"
+	"context"
+)
+
+type CommentEventHandler struct {
+	repo comments.CommentsRepository
+}
+
+func NewCommentEventHandler(repo comments.CommentsRepository) *CommentEventHandler {
+	return &CommentEventHandler{
+		repo: repo,
+	}
+}
+
+func (h *CommentEventHandler) Register(bus events.EventBus) {
+	bus.Subscribe(events.COMMENT_CREATED, "comments.cache", h.InvalidateCache)
+	bus.Subscribe(events.COMMENT_UPDATED, "comments.cache", h.InvalidateCache)
+	bus.Subscribe(events.COMMENT_DELETED, "comments.cache", h.InvalidateCache)
+}
+
+func (h *CommentEventHandler) InvalidateCache(ctx context.Context, event *events.Event) error {
+	var payload events.CommentPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	return h.repo.InvalidateCache(ctx, payload.PostID.String())
+}
@@ -4,7 +4,9 @@ import (
 	"bootcamp-content-interaction-service/domains/comments"
 	"bootcamp-content-interaction-service/domains/comments/models/request"
 	"bootcamp-content-interaction-service/domains/comments/models/response"
+	"bootcamp-content-interaction-service/domains/relations"
 	"bootcamp-content-interaction-service/domains/users/models/dto"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -49,6 +51,14 @@ func (h *CommentsHttp) CreateComment(c *gin.Context) {
 
 	err = h.uc.CreateComment(ctx, userId, postId, req.Msg, nil)
 
+	if errors.Is(err, relations.ErrBlocked) {
+		c.JSON(http.StatusForbidden,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest,
 			gin.H{
@@ -139,6 +149,14 @@ func (h *CommentsHttp) ReplyComment(c *gin.Context) {
 	commentId := strings.TrimPrefix(c.Param("comments_id"), ":")
 
 	err = h.uc.ReplyComment(ctx, commentId, userId, postId, req.Msg)
+	if errors.Is(err, relations.ErrBlocked) {
+		c.JSON(http.StatusForbidden,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest,
 			gin.H{
@@ -172,6 +190,14 @@ func (h *CommentsHttp) FindAllComment(c *gin.Context) {
 	postId := strings.TrimPrefix(c.Param("id"), ":")
 
 	comment, err := h.uc.FindAllComment(ctx, postId)
+	if errors.Is(err, comments.ErrPostNotFound) {
+		c.JSON(http.StatusNotFound,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest,
 			gin.H{
@@ -195,6 +221,7 @@ func (h *CommentsHttp) FindAllComment(c *gin.Context) {
 		response := response.CommentResponse{
 			ID:        i.ID,
 			UserID:    i.UserID,
+			PostId:    i.PostId,
 			ReplyId:   i.ReplyId,
 			CreatedAt: i.CreatedAt,
 			UpdatedAt: i.UpdatedAt,
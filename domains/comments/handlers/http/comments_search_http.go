@@ -0,0 +1,58 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/comments"
+	"bootcamp-content-interaction-service/domains/comments/models/request"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// SearchComments serves ?q= searches over comments. ?mode=prefix matches
+// the last word as a prefix, and later pages are fetched with the
+// next_cursor of the previous one.
+func (h *CommentsHttp) SearchComments(c *gin.Context) {
+	var req request.SearchRequest
+	ctx := c.Request.Context()
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	result, err := h.uc.SearchComments(ctx, &req)
+	if errors.Is(err, comments.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
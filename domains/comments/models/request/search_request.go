@@ -0,0 +1,10 @@
+package request
+
+// SearchRequest is a full-text search. Mode "words" matches whole words and
+// "prefix" treats the last word as a prefix, for search-as-you-type.
+type SearchRequest struct {
+	Q      string `form:"q" validate:"required,max=200"`
+	Mode   string `form:"mode" validate:"omitempty,oneof=words prefix"`
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
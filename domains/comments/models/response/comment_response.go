@@ -9,8 +9,16 @@ import (
 type CommentResponse struct{
 	ID        uuid.UUID  `json:"id"`
 	UserID    uuid.UUID  `json:"user_id"`
+	PostId    uuid.UUID  `json:"post_id"`
 	ReplyId   *uuid.UUID `json:"reply_id"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	Msg       string     `json:"msg"`
-}
\ No newline at end of file
+}
+
+// CommentSearchPage is one page of comment search results, best match
+// first.
+type CommentSearchPage struct {
+	Items      []*CommentResponse `json:"items"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
@@ -3,7 +3,10 @@ package repositories
 import (
 	comments "bootcamp-content-interaction-service/domains/comments"
 	"bootcamp-content-interaction-service/domains/comments/entities"
+	"bootcamp-content-interaction-service/domains/outbox"
+	posts "bootcamp-content-interaction-service/domains/posts/entities"
 	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/events"
 	"bootcamp-content-interaction-service/shared/util"
 	"context"
 	"encoding/json"
@@ -21,10 +24,11 @@ type CommentsRepository struct {
 	db infrastructures.Database
 	redisCache *redis.Client
     logger util.Logger
+	outbox outbox.OutboxRepository
 }
 
-func NewCommentsRepository(db infrastructures.Database, redisClient *redis.Client, logger util.Logger) comments.CommentsRepository {
-	return &CommentsRepository{db: db, redisCache: redisClient, logger: logger}
+func NewCommentsRepository(db infrastructures.Database, redisClient *redis.Client, logger util.Logger, outboxRepo outbox.OutboxRepository) comments.CommentsRepository {
+	return &CommentsRepository{db: db, redisCache: redisClient, logger: logger, outbox: outboxRepo}
 }
 
 func (repo *CommentsRepository) CreateComment(ctx context.Context, userId, postId, msg string, replyId *string) error {
@@ -59,25 +63,41 @@ func (repo *CommentsRepository) CreateComment(ctx context.Context, userId, postI
 		Msg:       msg,
 	}
 
-	err = repo.db.GetInstance().WithContext(ctx).Create(&comment).Error
-	if err != nil {
-		return errors.New("failed to create comment")
-	}
+	return repo.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post posts.Post
+		err := tx.Select("user_id").Where("id=?", pId).First(&post).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("post not found")
+		} else if err != nil {
+			return errors.New("failed to search post data")
+		}
 
-	cacheKey := "comments:post:" + postId
-	delErr := repo.redisCache.Del(ctx, cacheKey).Err()
-	if delErr != nil {
-		repo.logger.Warn("failed to delete redis cache",
-			zap.String("cacheKey", cacheKey),
-			zap.Error(delErr),
-		)
-	} else {
-		repo.logger.Info("deleted redis cache for comments",
-			zap.String("postId", postId),
-		)
-	}
+		payload := events.CommentPayload{
+			CommentID:   comment.ID,
+			PostID:      pId,
+			UserID:      uId,
+			PostOwnerID: post.UserID,
+			ReplyID:     rId,
+			Msg:         msg,
+			CreatedAt:   comment.CreatedAt,
+		}
 
-	return nil
+		if rId != nil {
+			var parent entities.Comments
+			err := tx.Select("user_id").Where("id=?", *rId).First(&parent).Error
+			if err != nil {
+				return errors.New("the comment_id that you reply doesn't exist")
+			}
+			payload.ReplyToUserID = &parent.UserID
+		}
+
+		err = tx.Create(&comment).Error
+		if err != nil {
+			return errors.New("failed to create comment")
+		}
+
+		return repo.outbox.Append(tx, events.COMMENT_CREATED, comment.ID, payload)
+	})
 }
 
 func (repo *CommentsRepository) UpdateComment(ctx context.Context, id, userId, msg string) error {
@@ -98,30 +118,26 @@ func (repo *CommentsRepository) UpdateComment(ctx context.Context, id, userId, m
 		return errors.New("authorization : you cannot update other comment")
 	}
 
-	err = repo.db.GetInstance().WithContext(ctx).Model(&comment).Unscoped().
-		Updates(map[string]interface{}{
-			"updated_at": time.Now(),
-			"msg":        msg,
-		}).Error
-
-	if err != nil {
-		return errors.New("failed to update comment")
-	}
+	return repo.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&comment).Unscoped().
+			Updates(map[string]interface{}{
+				"updated_at": time.Now(),
+				"msg":        msg,
+			}).Error
 
-	cacheKey := "comments:post:" + comment.PostId.String()
-	delErr := repo.redisCache.Del(ctx, cacheKey).Err()
-	if delErr != nil {
-		repo.logger.Warn("failed to delete redis cache",
-			zap.String("cacheKey", cacheKey),
-			zap.Error(delErr),
-		)
-	} else {
-		repo.logger.Info("deleted redis cache for comments",
-			zap.String("postId", comment.PostId.String()),
-		)
-	}
+		if err != nil {
+			return errors.New("failed to update comment")
+		}
 
-	return nil
+		return repo.outbox.Append(tx, events.COMMENT_UPDATED, comment.ID, events.CommentPayload{
+			CommentID: comment.ID,
+			PostID:    comment.PostId,
+			UserID:    comment.UserID,
+			ReplyID:   comment.ReplyId,
+			Msg:       msg,
+			CreatedAt: comment.CreatedAt,
+		})
+	})
 }
 
 func (repo *CommentsRepository) ReplyComment(ctx context.Context, id, userId, postId, msg string) error {
@@ -136,25 +152,7 @@ func (repo *CommentsRepository) ReplyComment(ctx context.Context, id, userId, po
 		return errors.New("the comment_id that you reply doesn't exist")
 	}
 
-	err = repo.CreateComment(ctx, userId, postId, msg, &id)
-	if err != nil {
-		return err
-	}
-
-	cacheKey := "comments:post:" + postId
-	delErr := repo.redisCache.Del(ctx, cacheKey).Err()
-	if delErr != nil {
-		repo.logger.Warn("failed to delete redis cache",
-			zap.String("cacheKey", cacheKey),
-			zap.Error(delErr),
-		)
-	} else {
-		repo.logger.Info("deleted redis cache for comments",
-			zap.String("postId", postId),
-		)
-	}
-
-	return nil
+	return repo.CreateComment(ctx, userId, postId, msg, &id)
 }
 
 func (repo *CommentsRepository) FindAllComment(ctx context.Context, postId string) (*[]entities.Comments, error) {
@@ -261,40 +259,105 @@ func (repo *CommentsRepository) DeleteComment(ctx context.Context, id uuid.UUID)
 		return errors.New("failed to find parent comment")
 	}
 
-	postId := parentComment.PostId.String()
-	
 	allReplies, err := repo.getAllReplyIDs(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if len(allReplies) > 0{
-		err = repo.db.GetInstance().WithContext(ctx).
-			Where("id IN ?", allReplies).
-			Delete(&entities.Comments{}).Error
+	return repo.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(allReplies) > 0{
+			err := tx.Where("id IN ?", allReplies).
+				Delete(&entities.Comments{}).Error
+			if err != nil {
+				return errors.New("failed to delete comment data : reply_id")
+			}
+		}
+
+		err := tx.Where("id=?", id).Delete(&entities.Comments{}).Error
 		if err != nil {
-			return errors.New("failed to delete comment data : reply_id")
+			return errors.New("failed to delete comment data : mother_id")
 		}
-	}
 
-	err = repo.db.GetInstance().WithContext(ctx).
-		Where("id=?", id).Delete(&entities.Comments{}).Error
-	if err != nil {
-		return errors.New("failed to delete comment data : mother_id")
-	}
+		return repo.outbox.Append(tx, events.COMMENT_DELETED, id, events.CommentPayload{
+			CommentID: id,
+			PostID:    parentComment.PostId,
+			UserID:    parentComment.UserID,
+			ReplyID:   parentComment.ReplyId,
+			EngagedAt: &parentComment.CreatedAt,
+		})
+	})
+}
 
-	cacheKey := "comments:post:" + postId
+func (repo *CommentsRepository) InvalidateCache(ctx context.Context, postId string) error {
+	cacheKey := "comments:posts:" + postId
 	delErr := repo.redisCache.Del(ctx, cacheKey).Err()
 	if delErr != nil {
 		repo.logger.Warn("failed to delete redis cache",
 			zap.String("cacheKey", cacheKey),
 			zap.Error(delErr),
 		)
-	} else {
-		repo.logger.Info("deleted redis cache for comments",
-			zap.String("postId", postId),
-		)
+		return delErr
 	}
 
+	repo.logger.Info("deleted redis cache for comments",
+		zap.String("postId", postId),
+	)
 	return nil
 }
+
+// SearchComments returns a page of the comments whose message matches
+// search, best first, and the cursor of the page after it, nil on the last
+// page. ExcludeUserIDs leaves out both their comments and the comments on
+// their posts.
+func (repo *CommentsRepository) SearchComments(ctx context.Context, search util.TextSearch) ([]entities.Comments, *util.ScoreCursor, error) {
+	found := []entities.Comments{}
+	tsquery, query, ok := search.TSQuery()
+	if !ok {
+		return found, nil, nil
+	}
+	score, decay := search.ScoreSQL("c.search_vector", "c.created_at")
+
+	sql := `
+		SELECT * FROM (
+			SELECT c.*, ` + score + ` AS score
+			FROM comments c
+			JOIN posts p ON p.id = c.post_id
+			CROSS JOIN ` + tsquery + ` search_query
+			WHERE c.search_vector @@ search_query`
+	args := []interface{}{decay, query}
+	if len(search.ExcludeUserIDs) > 0 {
+		sql += ` AND c.user_id NOT IN ? AND p.user_id NOT IN ?`
+		args = append(args, search.ExcludeUserIDs, search.ExcludeUserIDs)
+	}
+	sql += `
+		) matches`
+	if search.Cursor != nil {
+		sql += `
+		WHERE (score, id) < (?, ?)`
+		args = append(args, search.Cursor.Score, search.Cursor.ID)
+	}
+	sql += `
+		ORDER BY score DESC, id DESC
+		LIMIT ?`
+	args = append(args, search.Limit+1)
+
+	var rows []struct {
+		entities.Comments `gorm:"embedded"`
+		Score             float64
+	}
+	if err := repo.db.GetInstance().WithContext(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		repo.logger.Error("Failed to search comments", zap.Error(err))
+		return nil, nil, err
+	}
+
+	var next *util.ScoreCursor
+	if len(rows) > search.Limit {
+		rows = rows[:search.Limit]
+		last := rows[len(rows)-1]
+		next = &util.ScoreCursor{Score: last.Score, ID: last.ID}
+	}
+	for _, row := range rows {
+		found = append(found, row.Comments)
+	}
+	return found, next, nil
+}
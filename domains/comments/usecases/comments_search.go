@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	comments "bootcamp-content-interaction-service/domains/comments"
+	"bootcamp-content-interaction-service/domains/comments/models/request"
+	"bootcamp-content-interaction-service/domains/comments/models/response"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+)
+
+// SearchComments finds comments by their message, ranked by relevance and
+// recency. Comments by users hidden from the viewer, and comments on their
+// posts, are left out; anonymous viewers see every match.
+func (uc *CommentsUseCase) SearchComments(ctx context.Context, req *request.SearchRequest) (*response.CommentSearchPage, error) {
+	cursor, err := util.DecodeScoreCursor(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", comments.ErrInvalidCursor, err.Error())
+	}
+
+	search := util.TextSearch{
+		Query:    req.Q,
+		Prefix:   req.Mode == util.SEARCH_PREFIX,
+		HalfLife: uc.searchConf.HalfLife,
+		Cursor:   cursor,
+		Limit:    req.Limit,
+	}
+	if search.Limit <= 0 {
+		search.Limit = uc.searchConf.PageSize
+	}
+	if viewer, err := util.GetAuthUser(ctx); err == nil {
+		hidden, err := uc.relations.HiddenUserIDs(ctx, viewer.UserId)
+		if err != nil {
+			return nil, err
+		}
+		search.ExcludeUserIDs = hidden
+	}
+
+	found, next, err := uc.repo.SearchComments(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &response.CommentSearchPage{Items: []*response.CommentResponse{}}
+	for _, c := range found {
+		page.Items = append(page.Items, &response.CommentResponse{
+			ID:        c.ID,
+			UserID:    c.UserID,
+			PostId:    c.PostId,
+			ReplyId:   c.ReplyId,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+			Msg:       c.Msg,
+		})
+	}
+	if next != nil {
+		page.NextCursor = util.EncodeScoreCursor(next.Score, next.ID)
+	}
+	return page, nil
+}
@@ -1,22 +1,32 @@
 package usecases
 
 import (
+	"bootcamp-content-interaction-service/config"
 	comments "bootcamp-content-interaction-service/domains/comments"
 	"bootcamp-content-interaction-service/domains/comments/entities"
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/shared/util"
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
 
 type CommentsUseCase struct {
-	repo comments.CommentsRepository
+	repo       comments.CommentsRepository
+	relations  relations.RelationChecker
+	searchConf config.Search
 }
 
-func NewCommentsUseCase(repo comments.CommentsRepository) comments.CommentsUseCase {
-	return &CommentsUseCase{repo: repo}
+func NewCommentsUseCase(repo comments.CommentsRepository, relationChecker relations.RelationChecker, searchConf config.Search) comments.CommentsUseCase {
+	return &CommentsUseCase{repo: repo, relations: relationChecker, searchConf: searchConf.WithDefaults()}
 }
 
 func (uc *CommentsUseCase) CreateComment(ctx context.Context, userId, postId, msg string, replyId *string) error {
+	if err := uc.relations.CheckPostInteraction(ctx, userId, postId); err != nil {
+		return err
+	}
+
 	err := uc.repo.CreateComment(ctx, userId, postId, msg, nil)
 	if err != nil {
 		return err
@@ -35,6 +45,10 @@ func (uc *CommentsUseCase) UpdateComment(ctx context.Context, id, userId, msg st
 }
 
 func (uc *CommentsUseCase) ReplyComment(ctx context.Context, id, userId, postId, msg string) error {
+	if err := uc.relations.CheckPostInteraction(ctx, userId, postId); err != nil {
+		return err
+	}
+
 	err := uc.repo.ReplyComment(ctx, id, userId, postId, msg)
 	if err != nil {
 		return err
@@ -44,12 +58,49 @@ func (uc *CommentsUseCase) ReplyComment(ctx context.Context, id, userId, postId,
 }
 
 func (uc *CommentsUseCase) FindAllComment(ctx context.Context, postId string) (*[]entities.Comments,error) {
+	viewer, viewerErr := util.GetAuthUser(ctx)
+
+	// A viewer blocked from the post gets the same answer as for a post that
+	// does not exist, as ViewPostById gives them.
+	if viewerErr == nil {
+		err := uc.relations.CheckPostInteraction(ctx, viewer.UserId, postId)
+		if errors.Is(err, relations.ErrBlocked) {
+			return nil, comments.ErrPostNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	comment, err := uc.repo.FindAllComment(ctx, postId)
 	if err != nil {
 		return nil, err
 	}
 
-	return comment, nil
+	// Comments from users hidden from the viewer are left out of the thread.
+	if viewerErr != nil {
+		return comment, nil
+	}
+	hidden, err := uc.relations.HiddenUserIDs(ctx, viewer.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if len(hidden) == 0 {
+		return comment, nil
+	}
+
+	excluded := make(map[string]bool, len(hidden))
+	for _, id := range hidden {
+		excluded[id] = true
+	}
+	visible := []entities.Comments{}
+	for _, c := range *comment {
+		if !excluded[c.UserID.String()] {
+			visible = append(visible, c)
+		}
+	}
+
+	return &visible, nil
 }
 
 func (uc *CommentsUseCase) DeleteComment(ctx context.Context, id uuid.UUID) (error)  {
@@ -0,0 +1,30 @@
+package devices
+
+import (
+	"bootcamp-content-interaction-service/domains/devices/entities"
+	"bootcamp-content-interaction-service/domains/devices/models/requests"
+	"bootcamp-content-interaction-service/domains/devices/models/responses"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDeviceNotFound = errors.New("device not found")
+	ErrInvalidDevice  = errors.New("invalid device request")
+)
+
+type DeviceUseCase interface {
+	RegisterDevice(ctx context.Context, request *requests.RegisterDeviceRequest) (*responses.DeviceResponse, error)
+	FindAllDevice(ctx context.Context) ([]*responses.DeviceResponse, error)
+	DeleteDevice(ctx context.Context, deviceId string) error
+}
+
+type DeviceRepository interface {
+	SaveDevice(ctx context.Context, device *entities.DeviceToken) (*entities.DeviceToken, error)
+	FindDevices(ctx context.Context, userId string) ([]*entities.DeviceToken, error)
+	FindDevicesByUsers(ctx context.Context, userIds []uuid.UUID) ([]*entities.DeviceToken, error)
+	DeleteDevice(ctx context.Context, userId string, id string) error
+	DeleteTokens(ctx context.Context, tokens []string) (int64, error)
+}
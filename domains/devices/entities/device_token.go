@@ -0,0 +1,22 @@
+package entities
+
+import (
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceToken is a push token issued to one app install. Tokens are unique,
+// so registering a token again moves it to the user now signed in on that
+// device.
+type DeviceToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	User       users.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Platform   string     `gorm:"type:varchar(10);not null"`
+	Token      string     `gorm:"type:varchar(512);not null;uniqueIndex"`
+	LastSeenAt time.Time  `gorm:"type:timestamp"`
+	CreatedAt  time.Time  `gorm:"type:timestamp"`
+	UpdatedAt  time.Time  `gorm:"type:timestamp"`
+}
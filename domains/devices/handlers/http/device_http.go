@@ -0,0 +1,82 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/devices"
+	"bootcamp-content-interaction-service/domains/devices/models/requests"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type DeviceHttp struct {
+	deviceUc devices.DeviceUseCase
+}
+
+func NewDeviceHttp(deviceUc devices.DeviceUseCase) *DeviceHttp {
+	return &DeviceHttp{
+		deviceUc: deviceUc,
+	}
+}
+
+func (handler *DeviceHttp) RegisterDevice(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.RegisterDeviceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.deviceUc.RegisterDevice(ctx, &req)
+	if errors.Is(err, devices.ErrInvalidDevice) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (handler *DeviceHttp) ViewAllDevice(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := handler.deviceUc.FindAllDevice(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *DeviceHttp) DeleteDevice(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := handler.deviceUc.DeleteDevice(ctx, c.Param("id"))
+	if errors.Is(err, devices.ErrInvalidDevice) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, devices.ErrDeviceNotFound) {
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: "Device " + c.Param("id") + " deleted"})
+}
@@ -0,0 +1,6 @@
+package requests
+
+type RegisterDeviceRequest struct {
+	Token    string `json:"token" validate:"required,max=512"`
+	Platform string `json:"platform" validate:"required,oneof=ANDROID IOS WEB"`
+}
@@ -0,0 +1,9 @@
+package responses
+
+type DeviceResponse struct {
+	ID         string `json:"id"`
+	Platform   string `json:"platform"`
+	Token      string `json:"token"`
+	LastSeenAt string `json:"last_seen_at"`
+	CreatedAt  string `json:"created_at"`
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/devices"
+	"bootcamp-content-interaction-service/domains/devices/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+}
+
+func NewDeviceRepository(db infrastructures.Database, logger util.Logger) devices.DeviceRepository {
+	return DeviceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (d DeviceRepository) SaveDevice(ctx context.Context, device *entities.DeviceToken) (*entities.DeviceToken, error) {
+	now := time.Now()
+	device.ID = uuid.New()
+	device.LastSeenAt = now
+	device.CreatedAt = now
+	device.UpdatedAt = now
+
+	// On a known token RETURNING hands back the existing row, so the caller
+	// sees its original id and creation time.
+	result := d.db.GetInstance().WithContext(ctx).
+		Omit("User").
+		Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "token"}},
+				DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "last_seen_at", "updated_at"}),
+			},
+			clause.Returning{},
+		).
+		Create(device)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	d.logger.Info("Device token registered",
+		zap.String("user_id", device.UserID.String()),
+		zap.String("platform", device.Platform),
+	)
+
+	return device, nil
+}
+
+func (d DeviceRepository) FindDevices(ctx context.Context, userID string) ([]*entities.DeviceToken, error) {
+	var tokens []*entities.DeviceToken
+	result := d.db.GetInstance().WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+func (d DeviceRepository) FindDevicesByUsers(ctx context.Context, userIDs []uuid.UUID) ([]*entities.DeviceToken, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var tokens []*entities.DeviceToken
+	result := d.db.GetInstance().WithContext(ctx).Where("user_id IN ?", userIDs).Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+func (d DeviceRepository) DeleteDevice(ctx context.Context, userID string, id string) error {
+	result := d.db.GetInstance().WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entities.DeviceToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return devices.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (d DeviceRepository) DeleteTokens(ctx context.Context, tokens []string) (int64, error) {
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+
+	result := d.db.GetInstance().WithContext(ctx).Where("token IN ?", tokens).Delete(&entities.DeviceToken{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	d.logger.Info("Pruned invalid device tokens", zap.Int64("count", result.RowsAffected))
+
+	return result.RowsAffected, nil
+}
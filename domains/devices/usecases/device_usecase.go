@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/devices"
+	"bootcamp-content-interaction-service/domains/devices/entities"
+	"bootcamp-content-interaction-service/domains/devices/models/requests"
+	"bootcamp-content-interaction-service/domains/devices/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeviceUseCase struct {
+	deviceRepo devices.DeviceRepository
+}
+
+func NewDeviceUseCase(deviceRepo devices.DeviceRepository) devices.DeviceUseCase {
+	return DeviceUseCase{deviceRepo: deviceRepo}
+}
+
+func (d DeviceUseCase) RegisterDevice(ctx context.Context, request *requests.RegisterDeviceRequest) (*responses.DeviceResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(user.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user id", devices.ErrInvalidDevice)
+	}
+
+	token := strings.TrimSpace(request.Token)
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", devices.ErrInvalidDevice)
+	}
+
+	saved, err := d.deviceRepo.SaveDevice(ctx, &entities.DeviceToken{
+		UserID:   userID,
+		Platform: request.Platform,
+		Token:    token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeviceResponse(saved), nil
+}
+
+func (d DeviceUseCase) FindAllDevice(ctx context.Context) ([]*responses.DeviceResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := d.deviceRepo.FindDevices(ctx, user.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	responseList := []*responses.DeviceResponse{}
+	for _, token := range tokens {
+		responseList = append(responseList, toDeviceResponse(token))
+	}
+	return responseList, nil
+}
+
+func (d DeviceUseCase) DeleteDevice(ctx context.Context, deviceId string) error {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uuid.Parse(deviceId); err != nil {
+		return fmt.Errorf("%w: device id must be a UUID", devices.ErrInvalidDevice)
+	}
+
+	return d.deviceRepo.DeleteDevice(ctx, user.UserId, deviceId)
+}
+
+func toDeviceResponse(device *entities.DeviceToken) *responses.DeviceResponse {
+	return &responses.DeviceResponse{
+		ID:         device.ID.String(),
+		Platform:   device.Platform,
+		Token:      device.Token,
+		LastSeenAt: device.LastSeenAt.Format(time.RFC3339),
+		CreatedAt:  device.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -2,7 +2,9 @@ package http
 
 import (
 	"bootcamp-content-interaction-service/domains/likes"
+	"bootcamp-content-interaction-service/domains/relations"
 	"bootcamp-content-interaction-service/domains/users/models/dto"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -34,6 +36,14 @@ func (h *LikesHttp) LikePost(c *gin.Context) {
 
 	err := h.uc.LikePost(ctx, userId, postId)
 
+	if errors.Is(err, relations.ErrBlocked) {
+		c.JSON(http.StatusForbidden,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest,
 			gin.H{
@@ -67,6 +77,14 @@ func (h *LikesHttp) DislikePost(c *gin.Context) {
 
 	err := h.uc.DislikePost(ctx, userId, postId)
 
+	if errors.Is(err, relations.ErrBlocked) {
+		c.JSON(http.StatusForbidden,
+			gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest,
 			gin.H{
@@ -12,4 +12,5 @@ type LikesUseCase interface {
 type LikesRepository interface {
 	LikePost(ctx context.Context, userId, postId string) error
 	DislikePost(ctx context.Context, userId, postId string) error
+	CountLikes(ctx context.Context, postId string) (int64, error)
 }
\ No newline at end of file
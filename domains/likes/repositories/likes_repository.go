@@ -3,7 +3,10 @@ package repositories
 import (
 	likes "bootcamp-content-interaction-service/domains/likes"
 	"bootcamp-content-interaction-service/domains/likes/entities"
+	"bootcamp-content-interaction-service/domains/outbox"
+	post "bootcamp-content-interaction-service/domains/posts/entities"
 	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/events"
 	"context"
 	"errors"
 	"time"
@@ -13,16 +16,15 @@ import (
 )
 
 type LikesRepository struct {
-	db infrastructures.Database
+	db     infrastructures.Database
+	outbox outbox.OutboxRepository
 }
 
-func NewLikesRepository(db infrastructures.Database) likes.LikesRepository {
-	return &LikesRepository{db: db}
+func NewLikesRepository(db infrastructures.Database, outboxRepo outbox.OutboxRepository) likes.LikesRepository {
+	return &LikesRepository{db: db, outbox: outboxRepo}
 }
 
 func (repo *LikesRepository) LikePost(ctx context.Context, userId, postId string) error {
-	var likes entities.Likes
-
 	uId, err := uuid.Parse(userId)
 	if err != nil {
 		return errors.New("failed to parse userId")
@@ -33,65 +35,120 @@ func (repo *LikesRepository) LikePost(ctx context.Context, userId, postId string
 		return errors.New("failed to parse postId")
 	}
 
-	err = repo.db.GetInstance().WithContext(ctx).
-		Unscoped().
-		Where("user_id=? AND post_id=?", userId, postId).
-		First(&likes).Error
+	return repo.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var likes entities.Likes
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		likes = entities.Likes{
-			UserID:    uId,
-			PostId:    pId,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+		postOwnerId, err := findPostOwner(tx, pId)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().
+			Where("user_id=? AND post_id=?", userId, postId).
+			First(&likes).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			likes = entities.Likes{
+				UserID:    uId,
+				PostId:    pId,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}
+
+			err = tx.Create(&likes).Error
+			if err != nil {
+				return errors.New("failed adding to like database")
+			}
+		} else if err != nil {
+			return errors.New("failed to search like data")
+		} else if likes.DeletedAt.Valid {
+			err = tx.Unscoped().Model(&likes).
+				Updates(map[string]interface{}{
+					"deleted_at": nil,
+					"updated_at": time.Now(),
+				}).Error
+
+			if err != nil {
+				return errors.New("failed to update like data")
+			}
+		} else {
+			return nil
 		}
 
-		err = repo.db.GetInstance().WithContext(ctx).Create(&likes).Error
+		return repo.outbox.Append(tx, events.LIKE_ADDED, pId, events.LikePayload{
+			PostID:      pId,
+			UserID:      uId,
+			PostOwnerID: postOwnerId,
+		})
+	})
+}
+
+func (repo *LikesRepository) DislikePost(ctx context.Context, userId, postId string) error {
+	return repo.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var likes entities.Likes
+
+		err := tx.Unscoped().
+			Where("user_id=? AND post_id=?", userId, postId).
+			First(&likes).Error
+
 		if err != nil {
-			return errors.New("failed adding to like database")
+			return errors.New("failed to search like data")
 		}
-	}
 
-	if likes.DeletedAt.Valid {
-		err = repo.db.GetInstance().WithContext(ctx).Unscoped().Model(&likes).
+		if likes.DeletedAt.Valid {
+			return errors.New("you have dislike this post")
+		}
+		// A like restored after an unlike was last added at updated_at.
+		likedAt := likes.UpdatedAt
+
+		err = tx.Model(&likes).
 			Updates(map[string]interface{}{
-				"deleted_at": nil,
+				"deleted_at": time.Now(),
 				"updated_at": time.Now(),
 			}).Error
 
 		if err != nil {
-			return errors.New("failed to update like data")
+			return errors.New("failed to update like database")
+		}
+
+		postOwnerId, err := findPostOwner(tx, likes.PostId)
+		if err != nil {
+			return err
 		}
-	}
 
-	return nil
+		return repo.outbox.Append(tx, events.LIKE_REMOVED, likes.PostId, events.LikePayload{
+			PostID:      likes.PostId,
+			UserID:      likes.UserID,
+			PostOwnerID: postOwnerId,
+			EngagedAt:   &likedAt,
+		})
+	})
 }
 
-func (repo *LikesRepository) DislikePost(ctx context.Context, userId, postId string) error {
-	var likes entities.Likes
+func (repo *LikesRepository) CountLikes(ctx context.Context, postId string) (int64, error) {
+	var count int64
 
 	err := repo.db.GetInstance().WithContext(ctx).
-		Unscoped().
-		Where("user_id=? AND post_id=?", userId, postId).
-		First(&likes).Error
+		Model(&entities.Likes{}).
+		Where("post_id=?", postId).
+		Count(&count).Error
 
 	if err != nil {
-		return errors.New("failed to search like data")
+		return 0, errors.New("failed to count likes")
 	}
 
-	if !likes.DeletedAt.Valid {
-		err = repo.db.GetInstance().WithContext(ctx).Model(&likes).
-			Updates(map[string]interface{}{
-				"deleted_at": time.Now(),
-				"updated_at": time.Now(),
-			}).Error
+	return count, nil
+}
 
-		if err != nil {
-			return errors.New("failed to update like database")
-		}
-	}else{
-		return errors.New("you have dislike this post")
+func findPostOwner(tx *gorm.DB, postId uuid.UUID) (uuid.UUID, error) {
+	var owner post.Post
+
+	err := tx.Select("user_id").Where("id=?", postId).First(&owner).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, errors.New("post not found")
+	} else if err != nil {
+		return uuid.Nil, errors.New("failed to search post data")
 	}
 
-	return nil
+	return owner.UserID, nil
 }
@@ -2,18 +2,24 @@ package usecases
 
 import (
 	likes "bootcamp-content-interaction-service/domains/likes"
+	"bootcamp-content-interaction-service/domains/relations"
 	"context"
 )
 
 type LikesUseCase struct {
-	repo likes.LikesRepository
+	repo      likes.LikesRepository
+	relations relations.RelationChecker
 }
 
-func NewLikesUseCase(repo likes.LikesRepository) likes.LikesUseCase {
-	return &LikesUseCase{repo: repo}
+func NewLikesUseCase(repo likes.LikesRepository, relationChecker relations.RelationChecker) likes.LikesUseCase {
+	return &LikesUseCase{repo: repo, relations: relationChecker}
 }
 
 func (uc *LikesUseCase) LikePost(ctx context.Context, userId, postId string) error {
+	if err := uc.relations.CheckPostInteraction(ctx, userId, postId); err != nil {
+		return err
+	}
+
 	err := uc.repo.LikePost(ctx, userId, postId)
 	if err != nil {
 		return err
@@ -23,6 +29,10 @@ func (uc *LikesUseCase) LikePost(ctx context.Context, userId, postId string) err
 }
 
 func (uc *LikesUseCase) DislikePost(ctx context.Context, userId, postId string) error {
+	if err := uc.relations.CheckPostInteraction(ctx, userId, postId); err != nil {
+		return err
+	}
+
 	err := uc.repo.DislikePost(ctx, userId, postId)
 	if err != nil {
 		return err
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// MediaObject is one uploaded image or video stored by the SHA-256 of its
+// bytes. Renditions is a JSON map from rendition name to blob key. RefCount
+// is the number of post media pointing at it; the blobs go when it reaches
+// zero.
+type MediaObject struct {
+	Hash       string    `gorm:"type:char(64);primaryKey"`
+	MediaType  string    `gorm:"type:varchar(20);not null;default:'image'"`
+	Renditions string    `gorm:"type:jsonb;not null"`
+	Width      int       `gorm:"not null;default:0"`
+	Height     int       `gorm:"not null;default:0"`
+	DurationMs int64     `gorm:"not null;default:0"`
+	RefCount   int       `gorm:"not null;default:0"`
+	CreatedAt  time.Time `gorm:"type:timestamp"`
+	UpdatedAt  time.Time `gorm:"type:timestamp"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// UploadSession collects one image in chunks before it goes through the
+// image pipeline. Chunks lists the blob key of every chunk received, in
+// order, and Received is their total length. Once completed the chunks are
+// gone and the media columns describe the stored image or video until a post
+// claims it; the ID is the media ID the post refers to.
+type UploadSession struct {
+	ID         uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID     uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Size       int64          `gorm:"not null"`
+	Received   int64          `gorm:"not null;default:0"`
+	Chunks     pq.StringArray `gorm:"type:text[]"`
+	Status     string         `gorm:"type:varchar(20);not null"`
+	MediaType  string         `gorm:"type:varchar(20)"`
+	Renditions string         `gorm:"type:jsonb;not null;default:'{}'"`
+	Width      int            `gorm:"not null;default:0"`
+	Height     int            `gorm:"not null;default:0"`
+	DurationMs int64          `gorm:"not null;default:0"`
+	ExpiresAt  time.Time      `gorm:"type:timestamp;not null;index"`
+	CreatedAt  time.Time      `gorm:"type:timestamp"`
+	UpdatedAt  time.Time      `gorm:"type:timestamp"`
+}
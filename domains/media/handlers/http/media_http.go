@@ -0,0 +1,65 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHttp struct {
+	mediaUc media.MediaUseCase
+}
+
+func NewMediaHttp(mediaUc media.MediaUseCase) *MediaHttp {
+	return &MediaHttp{
+		mediaUc: mediaUc,
+	}
+}
+
+// ViewMedia serves a stored object behind a signed URL. Range requests and
+// If-None-Match / If-Modified-Since revalidation are handled by
+// http.ServeContent; caches may keep the response until the link expires.
+func (handler *MediaHttp) ViewMedia(c *gin.Context) {
+	ctx := c.Request.Context()
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	blob, expiresAt, err := handler.mediaUc.OpenMedia(ctx, key, c.Query("expires"), c.Query("signature"))
+	if errors.Is(err, media.ErrMediaForbidden) {
+		c.JSON(http.StatusForbidden, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, media.ErrMediaNotFound) {
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	defer blob.Body.Close()
+
+	contentType := blob.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	maxAge := int(time.Until(expiresAt).Seconds())
+	if maxAge < 0 {
+		maxAge = 0
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Cache-Control", "private, max-age="+strconv.Itoa(maxAge))
+	header.Set("X-Content-Type-Options", "nosniff")
+	if blob.ETag != "" {
+		header.Set("ETag", blob.ETag)
+	}
+
+	http.ServeContent(c.Writer, c.Request, "", blob.ModTime, blob.Body)
+}
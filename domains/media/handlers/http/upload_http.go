@@ -0,0 +1,109 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/domains/media/models/requests"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type UploadHttp struct {
+	uploadUc media.UploadUseCase
+}
+
+func NewUploadHttp(uploadUc media.UploadUseCase) *UploadHttp {
+	return &UploadHttp{
+		uploadUc: uploadUc,
+	}
+}
+
+func (handler *UploadHttp) InitiateUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.InitiateUploadRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.uploadUc.InitiateUpload(ctx, &req)
+	if err != nil {
+		writeUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (handler *UploadHttp) ViewUpload(c *gin.Context) {
+	result, err := handler.uploadUc.ViewUpload(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeUploadError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(result.Received, 10))
+	c.JSON(http.StatusOK, result)
+}
+
+// WriteChunk takes the raw request body as the chunk starting at ?offset=.
+// Upload-Offset always carries the offset to send next; on a conflict the
+// client resumes from there.
+func (handler *UploadHttp) WriteChunk(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.Query("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "offset must be a non-negative integer"})
+		return
+	}
+
+	result, err := handler.uploadUc.WriteChunk(c.Request.Context(), c.Param("id"), offset, c.Request.Body)
+	if result != nil {
+		c.Header("Upload-Offset", strconv.FormatInt(result.Received, 10))
+	}
+	if errors.Is(err, media.ErrUploadOffsetMismatch) {
+		c.JSON(http.StatusConflict, responses.BasicResponse{Data: result, Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *UploadHttp) CompleteUpload(c *gin.Context) {
+	result, err := handler.uploadUc.CompleteUpload(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeUploadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, media.ErrUploadNotFound):
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, media.ErrUploadIncomplete), errors.Is(err, media.ErrInvalidChunk):
+		c.JSON(http.StatusConflict, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, media.ErrUnsupportedMedia):
+		c.JSON(http.StatusUnsupportedMediaType, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, media.ErrMediaTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, responses.BasicResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+	}
+}
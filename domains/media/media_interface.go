@@ -0,0 +1,95 @@
+package media
+
+import (
+	"bootcamp-content-interaction-service/domains/media/entities"
+	"bootcamp-content-interaction-service/domains/media/models/requests"
+	"bootcamp-content-interaction-service/domains/media/models/responses"
+	"bootcamp-content-interaction-service/infrastructures"
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrMediaNotFound    = errors.New("media not found")
+	ErrMediaForbidden   = errors.New("media link is invalid or expired")
+	ErrUnsupportedMedia = errors.New("unsupported media type")
+	ErrMediaTooLarge    = errors.New("media too large")
+
+	ErrUploadNotFound       = errors.New("upload not found")
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	ErrUploadIncomplete     = errors.New("upload is incomplete")
+	ErrInvalidChunk         = errors.New("invalid upload chunk")
+)
+
+// StoredMedia is an upload as stored: its renditions by name
+// (util.RENDITION_*) and what the server read from the content itself.
+type StoredMedia struct {
+	Type       string
+	Renditions map[string]string
+	Width      int
+	Height     int
+	DurationMs int64
+}
+
+type MediaUseCase interface {
+	OpenMedia(ctx context.Context, key string, expires string, signature string) (*infrastructures.Blob, time.Time, error)
+}
+
+// UploadUseCase receives an image in chunks: a session is initiated with the
+// total size, chunks are written at the offset received so far, and
+// completing the session stores the image under the session ID, which posts
+// then claim.
+type UploadUseCase interface {
+	UploadClaimer
+
+	InitiateUpload(ctx context.Context, request *requests.InitiateUploadRequest) (*responses.UploadResponse, error)
+	ViewUpload(ctx context.Context, uploadId string) (*responses.UploadResponse, error)
+	WriteChunk(ctx context.Context, uploadId string, offset int64, chunk io.Reader) (*responses.UploadResponse, error)
+	CompleteUpload(ctx context.Context, uploadId string) (*responses.UploadResponse, error)
+}
+
+// UploadClaimer hands the images of completed uploads over to their user's
+// post. The caller owns the returned renditions from then on and releases
+// them through the ImagePipeline when it no longer needs them.
+type UploadClaimer interface {
+	ClaimUploads(ctx context.Context, userId string, uploadIds []string) ([]*StoredMedia, error)
+}
+
+type UploadRepository interface {
+	CreateSession(ctx context.Context, session *entities.UploadSession) (*entities.UploadSession, error)
+	FindSession(ctx context.Context, userId string, id string) (*entities.UploadSession, error)
+	AppendChunk(ctx context.Context, userId string, id string, offset int64, length int64, key string, expiresAt time.Time) (*entities.UploadSession, error)
+	StartProcessing(ctx context.Context, userId string, id string) (*entities.UploadSession, error)
+	FinishProcessing(ctx context.Context, id string, stored *StoredMedia, expiresAt time.Time) (bool, error)
+	ResetProcessing(ctx context.Context, id string) error
+	DeleteSession(ctx context.Context, id string) error
+	ClaimSessions(ctx context.Context, userId string, ids []string) ([]*entities.UploadSession, error)
+	DeleteExpired(ctx context.Context, now time.Time, limit int) ([]*entities.UploadSession, error)
+}
+
+// ImagePipeline turns an upload into stored renditions, keyed by rendition
+// name (util.RENDITION_*). Identical uploads share one stored copy, so every
+// StoreImage must be balanced by releasing the renditions it returned.
+type ImagePipeline interface {
+	StoreImage(ctx context.Context, body io.Reader) (*StoredMedia, error)
+	ReleaseImages(ctx context.Context, images []map[string]string)
+}
+
+// VideoPipeline validates and stores the video at path, with a poster frame
+// when one can be extracted. Stored videos are shared and released the same
+// way as images, through the ImagePipeline.
+type VideoPipeline interface {
+	StoreVideo(ctx context.Context, path string) (*StoredMedia, error)
+}
+
+// MediaRepository reference-counts content-addressed media. Acquire takes a
+// reference on an existing hash; Create records a newly stored one with a
+// single reference; Release drops one and calls onLast, under lock, before
+// forgetting a hash nobody references any more.
+type MediaRepository interface {
+	Acquire(ctx context.Context, hash string) (*StoredMedia, bool, error)
+	Create(ctx context.Context, hash string, stored *StoredMedia) error
+	Release(ctx context.Context, hash string, onLast func(renditions map[string]string) error) error
+}
@@ -0,0 +1,5 @@
+package requests
+
+type InitiateUploadRequest struct {
+	Size int64 `json:"size" validate:"required,min=1"`
+}
@@ -0,0 +1,9 @@
+package responses
+
+type UploadResponse struct {
+	ID        string `json:"id"`
+	Size      int64  `json:"size"`
+	Received  int64  `json:"received"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at"`
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/domains/media/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MediaRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+}
+
+func NewMediaRepository(db infrastructures.Database, logger util.Logger) media.MediaRepository {
+	return MediaRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m MediaRepository) Acquire(ctx context.Context, hash string) (*media.StoredMedia, bool, error) {
+	var object entities.MediaObject
+	result := m.db.GetInstance().WithContext(ctx).
+		Model(&object).
+		Clauses(clause.Returning{}).
+		Where("hash = ?", hash).
+		UpdateColumns(map[string]interface{}{
+			"ref_count":  gorm.Expr("ref_count + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, false, nil
+	}
+
+	renditions, err := decodeRenditions(object.Renditions)
+	if err != nil {
+		return nil, false, err
+	}
+	return &media.StoredMedia{
+		Type:       object.MediaType,
+		Renditions: renditions,
+		Width:      object.Width,
+		Height:     object.Height,
+		DurationMs: object.DurationMs,
+	}, true, nil
+}
+
+func (m MediaRepository) Create(ctx context.Context, hash string, stored *media.StoredMedia) error {
+	encoded, err := json.Marshal(stored.Renditions)
+	if err != nil {
+		return err
+	}
+
+	// A concurrent upload of the same bytes may have got here first; its
+	// blobs have the same keys, so both uploads share the row.
+	return m.db.GetInstance().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "hash"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"ref_count":  gorm.Expr("media_objects.ref_count + 1"),
+				"updated_at": time.Now(),
+			}),
+		}).
+		Create(&entities.MediaObject{
+			Hash:       hash,
+			MediaType:  stored.Type,
+			Renditions: string(encoded),
+			Width:      stored.Width,
+			Height:     stored.Height,
+			DurationMs: stored.DurationMs,
+			RefCount:   1,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}).Error
+}
+
+func (m MediaRepository) Release(ctx context.Context, hash string, onLast func(renditions map[string]string) error) error {
+	return m.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var object entities.MediaObject
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("hash = ?", hash).
+			First(&object).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if object.RefCount > 1 {
+			return tx.Model(&object).UpdateColumns(map[string]interface{}{
+				"ref_count":  gorm.Expr("ref_count - 1"),
+				"updated_at": time.Now(),
+			}).Error
+		}
+
+		// The row stays locked while the blobs go, so an upload of the same
+		// bytes waits in Acquire and then stores them afresh.
+		renditions, err := decodeRenditions(object.Renditions)
+		if err != nil {
+			return err
+		}
+		if err := onLast(renditions); err != nil {
+			return err
+		}
+		return tx.Delete(&object).Error
+	})
+}
+
+func decodeRenditions(encoded string) (map[string]string, error) {
+	var renditions map[string]string
+	if err := json.Unmarshal([]byte(encoded), &renditions); err != nil {
+		return nil, err
+	}
+	return renditions, nil
+}
@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/domains/media/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UploadRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+}
+
+func NewUploadRepository(db infrastructures.Database, logger util.Logger) media.UploadRepository {
+	return UploadRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (u UploadRepository) CreateSession(ctx context.Context, session *entities.UploadSession) (*entities.UploadSession, error) {
+	now := time.Now()
+	session.ID = uuid.New()
+	session.Status = util.UPLOAD_PENDING
+	session.Renditions = "{}"
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	if err := u.db.GetInstance().WithContext(ctx).Create(session).Error; err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// FindSession treats an expired session as gone; the cleanup worker may not
+// have removed it yet.
+func (u UploadRepository) FindSession(ctx context.Context, userId string, id string) (*entities.UploadSession, error) {
+	var session entities.UploadSession
+	result := u.db.GetInstance().WithContext(ctx).
+		Where("id = ? AND user_id = ? AND expires_at > ?", id, userId, time.Now()).
+		First(&session)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, media.ErrUploadNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &session, nil
+}
+
+// AppendChunk records a chunk only if the session is still pending and has
+// received exactly offset bytes, so of two writes racing for the same
+// offset one wins and the other gets ErrUploadOffsetMismatch.
+func (u UploadRepository) AppendChunk(ctx context.Context, userId string, id string, offset int64, length int64, key string, expiresAt time.Time) (*entities.UploadSession, error) {
+	var session entities.UploadSession
+	result := u.db.GetInstance().WithContext(ctx).
+		Model(&session).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND user_id = ? AND status = ? AND received = ? AND received + ? <= size AND expires_at > ?",
+			id, userId, util.UPLOAD_PENDING, offset, length, time.Now()).
+		UpdateColumns(map[string]interface{}{
+			"received":   gorm.Expr("received + ?", length),
+			"chunks":     gorm.Expr("array_append(chunks, ?)", key),
+			"expires_at": expiresAt,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, media.ErrUploadOffsetMismatch
+	}
+
+	return &session, nil
+}
+
+// StartProcessing moves a fully received session to processing, which keeps
+// concurrent completions from storing the image twice.
+func (u UploadRepository) StartProcessing(ctx context.Context, userId string, id string) (*entities.UploadSession, error) {
+	var session entities.UploadSession
+	result := u.db.GetInstance().WithContext(ctx).
+		Model(&session).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND user_id = ? AND status = ? AND received = size AND expires_at > ?",
+			id, userId, util.UPLOAD_PENDING, time.Now()).
+		UpdateColumns(map[string]interface{}{
+			"status":     util.UPLOAD_PROCESSING,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, media.ErrUploadIncomplete
+	}
+
+	return &session, nil
+}
+
+// FinishProcessing reports false when the session was removed while it was
+// being processed, in which case nobody will claim the renditions.
+func (u UploadRepository) FinishProcessing(ctx context.Context, id string, stored *media.StoredMedia, expiresAt time.Time) (bool, error) {
+	encoded, err := json.Marshal(stored.Renditions)
+	if err != nil {
+		return false, err
+	}
+
+	result := u.db.GetInstance().WithContext(ctx).
+		Model(&entities.UploadSession{}).
+		Where("id = ? AND status = ?", id, util.UPLOAD_PROCESSING).
+		UpdateColumns(map[string]interface{}{
+			"status":      util.UPLOAD_COMPLETED,
+			"media_type":  stored.Type,
+			"renditions":  string(encoded),
+			"width":       stored.Width,
+			"height":      stored.Height,
+			"duration_ms": stored.DurationMs,
+			"chunks":      gorm.Expr("'{}'::text[]"),
+			"expires_at":  expiresAt,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (u UploadRepository) ResetProcessing(ctx context.Context, id string) error {
+	return u.db.GetInstance().WithContext(ctx).
+		Model(&entities.UploadSession{}).
+		Where("id = ? AND status = ?", id, util.UPLOAD_PROCESSING).
+		UpdateColumns(map[string]interface{}{
+			"status":     util.UPLOAD_PENDING,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (u UploadRepository) DeleteSession(ctx context.Context, id string) error {
+	return u.db.GetInstance().WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&entities.UploadSession{}).Error
+}
+
+// ClaimSessions removes the given completed sessions and returns them in the
+// order asked for. It claims all of them or, when any is missing, expired,
+// unfinished or listed twice, none.
+func (u UploadRepository) ClaimSessions(ctx context.Context, userId string, ids []string) ([]*entities.UploadSession, error) {
+	var claimed []*entities.UploadSession
+	err := u.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sessions []*entities.UploadSession
+		result := tx.Clauses(clause.Returning{}).
+			Where("id IN ? AND user_id = ? AND status = ? AND expires_at > ?",
+				ids, userId, util.UPLOAD_COMPLETED, time.Now()).
+			Delete(&sessions)
+		if result.Error != nil {
+			return result.Error
+		}
+		if len(sessions) != len(ids) {
+			return media.ErrUploadNotFound
+		}
+
+		byID := make(map[string]*entities.UploadSession, len(sessions))
+		for _, session := range sessions {
+			byID[session.ID.String()] = session
+		}
+		for _, id := range ids {
+			session, ok := byID[id]
+			if !ok {
+				return media.ErrUploadNotFound
+			}
+			claimed = append(claimed, session)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// DeleteExpired removes up to limit sessions that expired before now and
+// returns them so their chunks and images can be cleaned up.
+func (u UploadRepository) DeleteExpired(ctx context.Context, now time.Time, limit int) ([]*entities.UploadSession, error) {
+	var sessions []*entities.UploadSession
+	result := u.db.GetInstance().WithContext(ctx).Raw(`
+		DELETE FROM upload_sessions
+		WHERE id IN (
+			SELECT id FROM upload_sessions
+			WHERE expires_at <= ?
+			ORDER BY expires_at
+			LIMIT ?
+		)
+		RETURNING *`, now, limit).
+		Scan(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
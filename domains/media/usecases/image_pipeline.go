@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.uber.org/zap"
+)
+
+// allowedImageTypes are the formats the standard library can decode, so
+// they are the ones that can be re-encoded without their metadata.
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// contentKeyPrefix starts every content-addressed key; the hash of the
+// upload follows it up to the next slash.
+const contentKeyPrefix = "media/"
+
+type ImagePipeline struct {
+	blobStore       infrastructures.BlobStore
+	mediaRepository media.MediaRepository
+	conf            config.Media
+	logger          util.Logger
+}
+
+func NewImagePipeline(blobStore infrastructures.BlobStore, mediaRepository media.MediaRepository, conf config.Media, logger util.Logger) media.ImagePipeline {
+	return ImagePipeline{
+		blobStore:       blobStore,
+		mediaRepository: mediaRepository,
+		conf:            conf.WithDefaults(),
+		logger:          logger,
+	}
+}
+
+// StoreImage validates an upload by its content rather than its name,
+// decodes it, turns it upright and stores every rendition as a fresh
+// encoding. Re-encoding drops EXIF and any other embedded metadata, GPS
+// position included. Opaque images are stored as JPEG, the rest as PNG.
+//
+// Renditions are keyed by the SHA-256 of the upload, so an image uploaded
+// again is not processed a second time: it takes another reference on the
+// copy already stored.
+func (i ImagePipeline) StoreImage(ctx context.Context, body io.Reader) (*media.StoredMedia, error) {
+	data, err := io.ReadAll(io.LimitReader(body, i.conf.MaxUploadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > i.conf.MaxUploadBytes {
+		return nil, fmt.Errorf("%w: limit is %d bytes", media.ErrMediaTooLarge, i.conf.MaxUploadBytes)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	stored, found, err := i.mediaRepository.Acquire(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return stored, nil
+	}
+
+	detected := mimetype.Detect(data)
+	if !allowedImageTypes[detected.String()] {
+		return nil, fmt.Errorf("%w: %s", media.ErrUnsupportedMedia, detected.String())
+	}
+
+	// Check the dimensions before decoding so a small file cannot expand
+	// into a huge bitmap.
+	header, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", media.ErrUnsupportedMedia, err.Error())
+	}
+	if header.Width*header.Height > i.conf.MaxPixels {
+		return nil, fmt.Errorf("%w: limit is %d pixels", media.ErrMediaTooLarge, i.conf.MaxPixels)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", media.ErrUnsupportedMedia, err.Error())
+	}
+
+	upright := toRGBA(decoded)
+	if detected.Is("image/jpeg") {
+		upright = orient(upright, jpegOrientation(data))
+	}
+
+	keys, err := i.storeRenditions(ctx, hash, upright)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := upright.Bounds()
+	stored = &media.StoredMedia{
+		Type:       util.MEDIA_IMAGE,
+		Renditions: keys,
+		Width:      bounds.Dx(),
+		Height:     bounds.Dy(),
+	}
+	if err := i.mediaRepository.Create(ctx, hash, stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// storeRenditions stores the thumbnail, feed and full renditions of img
+// under hash. Blobs already written are left behind on failure: another
+// upload of the same bytes may be writing, or may already own, the same
+// keys.
+func (i ImagePipeline) storeRenditions(ctx context.Context, hash string, img *image.RGBA) (map[string]string, error) {
+	renditions := map[string]*image.RGBA{
+		util.RENDITION_THUMBNAIL: fit(cropSquare(img), i.conf.ThumbnailSize),
+		util.RENDITION_FEED:      fit(img, i.conf.FeedSize),
+		util.RENDITION_FULL:      fit(img, i.conf.FullSize),
+	}
+
+	keys := make(map[string]string, len(renditions))
+	for name, rendition := range renditions {
+		key, err := i.storeRendition(ctx, hash, name, rendition)
+		if err != nil {
+			return nil, err
+		}
+		keys[name] = key
+	}
+	return keys, nil
+}
+
+// ReleaseImages drops one reference per image or video and removes the
+// blobs of one nobody references any more. Images stored before content
+// addressing have their blobs removed straight away. Failures are logged: they leave an
+// orphaned object but never fail the request that dropped the image.
+func (i ImagePipeline) ReleaseImages(ctx context.Context, images []map[string]string) {
+	for _, keys := range images {
+		hash, ok := renditionsHash(keys)
+		if !ok {
+			i.deleteBlobs(ctx, keys)
+			continue
+		}
+
+		err := i.mediaRepository.Release(ctx, hash, func(renditions map[string]string) error {
+			i.deleteBlobs(ctx, renditions)
+			return nil
+		})
+		if err != nil {
+			i.logger.Error("Failed to release image",
+				zap.String("hash", hash),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (i ImagePipeline) deleteBlobs(ctx context.Context, keys map[string]string) {
+	for _, key := range keys {
+		if err := i.blobStore.Delete(ctx, key); err != nil {
+			i.logger.Error("Failed to remove image",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// renditionsHash returns the upload hash the renditions were stored under,
+// and false for renditions from before content addressing.
+func renditionsHash(keys map[string]string) (string, bool) {
+	for _, key := range keys {
+		return contentHash(key)
+	}
+	return "", false
+}
+
+// contentHash returns the upload hash a content-addressed key was stored
+// under, and false for keys from before content addressing.
+func contentHash(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, contentKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	hash, _, ok := strings.Cut(rest, "/")
+	if !ok || len(hash) != sha256.Size*2 {
+		return "", false
+	}
+	return hash, true
+}
+
+func (i ImagePipeline) storeRendition(ctx context.Context, hash string, name string, img *image.RGBA) (string, error) {
+	var buf bytes.Buffer
+	key, contentType := contentKeyPrefix+hash+"/"+name+".jpg", "image/jpeg"
+
+	var err error
+	if img.Opaque() {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: i.conf.JPEGQuality})
+	} else {
+		key, contentType = contentKeyPrefix+hash+"/"+name+".png", "image/png"
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := i.blobStore.Put(ctx, key, &buf, int64(buf.Len()), contentType); err != nil {
+		return "", err
+	}
+	return key, nil
+}
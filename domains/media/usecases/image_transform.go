@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"image"
+	"image/draw"
+)
+
+func toRGBA(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// orient turns src upright according to an EXIF orientation value.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			si := src.PixOffset(sx, sy)
+			di := dst.PixOffset(x, y)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+// cropSquare cuts the largest centred square out of src.
+func cropSquare(src *image.RGBA) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	side := min(w, h)
+	x0, y0 := (w-side)/2, (h-side)/2
+	return src.SubImage(image.Rect(x0, y0, x0+side, y0+side)).(*image.RGBA)
+}
+
+// fit scales src down so its long edge is at most maxEdge. Smaller images
+// are returned as they are; nothing is scaled up.
+func fit(src *image.RGBA, maxEdge int) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if w <= maxEdge && h <= maxEdge {
+		return src
+	}
+
+	if w >= h {
+		return resize(src, maxEdge, max(1, h*maxEdge/w))
+	}
+	return resize(src, max(1, w*maxEdge/h), maxEdge)
+}
+
+// resize downsamples by area averaging: each destination pixel is the mean
+// of the source pixels it covers. The pixels are premultiplied, so the mean
+// is correct for translucent images too.
+func resize(src *image.RGBA, dw int, dh int) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		sy0 := y * sh / dh
+		sy1 := max((y+1)*sh/dh, sy0+1)
+		for x := 0; x < dw; x++ {
+			sx0 := x * sw / dw
+			sx1 := max((x+1)*sw/dw, sx0+1)
+
+			var r, g, b, a uint64
+			for sy := sy0; sy < sy1; sy++ {
+				i := src.PixOffset(bounds.Min.X+sx0, bounds.Min.Y+sy)
+				for sx := sx0; sx < sx1; sx++ {
+					r += uint64(src.Pix[i])
+					g += uint64(src.Pix[i+1])
+					b += uint64(src.Pix[i+2])
+					a += uint64(src.Pix[i+3])
+					i += 4
+				}
+			}
+
+			n := uint64((sy1 - sy0) * (sx1 - sx0))
+			di := dst.PixOffset(x, y)
+			dst.Pix[di] = uint8(r / n)
+			dst.Pix[di+1] = uint8(g / n)
+			dst.Pix[di+2] = uint8(b / n)
+			dst.Pix[di+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/infrastructures"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type MediaUseCase struct {
+	blobStore infrastructures.BlobStore
+	signer    *infrastructures.URLSigner
+}
+
+func NewMediaUseCase(blobStore infrastructures.BlobStore, signer *infrastructures.URLSigner) media.MediaUseCase {
+	return MediaUseCase{
+		blobStore: blobStore,
+		signer:    signer,
+	}
+}
+
+// OpenMedia checks the URL signature and reads the stored object, returning
+// when the link expires. Invalid keys are reported as missing so probing for
+// paths outside the store looks the same as a miss.
+func (m MediaUseCase) OpenMedia(ctx context.Context, key string, expires string, signature string) (*infrastructures.Blob, time.Time, error) {
+	expiresAt, err := m.signer.Verify(key, expires, signature)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: %s", media.ErrMediaForbidden, err.Error())
+	}
+
+	blob, err := m.blobStore.Get(ctx, key)
+	if errors.Is(err, infrastructures.ErrBlobNotFound) || errors.Is(err, infrastructures.ErrInvalidBlobKey) {
+		return nil, time.Time{}, media.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return blob, expiresAt, nil
+}
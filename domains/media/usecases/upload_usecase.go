@@ -0,0 +1,321 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/domains/media/entities"
+	"bootcamp-content-interaction-service/domains/media/models/requests"
+	"bootcamp-content-interaction-service/domains/media/models/responses"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type UploadUseCase struct {
+	uploadRepository media.UploadRepository
+	blobStore        infrastructures.BlobStore
+	imagePipeline    media.ImagePipeline
+	videoPipeline    media.VideoPipeline
+	conf             config.Media
+	logger           util.Logger
+}
+
+func NewUploadUseCase(uploadRepo media.UploadRepository, blobStore infrastructures.BlobStore, imagePipeline media.ImagePipeline, videoPipeline media.VideoPipeline, conf config.Media, logger util.Logger) media.UploadUseCase {
+	return UploadUseCase{
+		uploadRepository: uploadRepo,
+		blobStore:        blobStore,
+		imagePipeline:    imagePipeline,
+		videoPipeline:    videoPipeline,
+		conf:             conf.WithDefaults(),
+		logger:           logger,
+	}
+}
+
+func (u UploadUseCase) InitiateUpload(ctx context.Context, request *requests.InitiateUploadRequest) (*responses.UploadResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(user.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+	// The content type is only known once the upload completes, so the size
+	// is checked against the larger limit here and the exact one then.
+	limit := u.conf.MaxUploadBytes
+	if u.conf.MaxVideoBytes > limit {
+		limit = u.conf.MaxVideoBytes
+	}
+	if request.Size > limit {
+		return nil, fmt.Errorf("%w: limit is %d bytes", media.ErrMediaTooLarge, limit)
+	}
+
+	session, err := u.uploadRepository.CreateSession(ctx, &entities.UploadSession{
+		UserID:    userID,
+		Size:      request.Size,
+		ExpiresAt: time.Now().Add(u.conf.UploadTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUploadResponse(session), nil
+}
+
+func (u UploadUseCase) ViewUpload(ctx context.Context, uploadId string) (*responses.UploadResponse, error) {
+	session, err := u.findSession(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUploadResponse(session), nil
+}
+
+// WriteChunk appends chunk to the upload if offset is where the upload
+// stands. On ErrUploadOffsetMismatch the returned upload tells the client
+// where to resume, which also covers retrying a chunk that had already
+// arrived.
+func (u UploadUseCase) WriteChunk(ctx context.Context, uploadId string, offset int64, chunk io.Reader) (*responses.UploadResponse, error) {
+	session, err := u.findSession(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != util.UPLOAD_PENDING {
+		return toUploadResponse(session), fmt.Errorf("%w: upload is %s", media.ErrInvalidChunk, session.Status)
+	}
+	if offset != session.Received {
+		return toUploadResponse(session), fmt.Errorf("%w: expected offset %d", media.ErrUploadOffsetMismatch, session.Received)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(chunk, u.conf.MaxChunkBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > u.conf.MaxChunkBytes {
+		return nil, fmt.Errorf("%w: chunk limit is %d bytes", media.ErrMediaTooLarge, u.conf.MaxChunkBytes)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: chunk is empty", media.ErrInvalidChunk)
+	}
+	if offset+int64(len(data)) > session.Size {
+		return nil, fmt.Errorf("%w: chunk ends past the upload size of %d bytes", media.ErrInvalidChunk, session.Size)
+	}
+
+	// Each write gets its own key, so a losing write racing for the same
+	// offset never overwrites the chunk that won.
+	key := "uploads/" + session.ID.String() + "/" + strconv.FormatInt(offset, 10) + "-" + uuid.NewString()
+	if err := u.blobStore.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "application/octet-stream"); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.uploadRepository.AppendChunk(ctx, session.UserID.String(), uploadId, offset, int64(len(data)), key, time.Now().Add(u.conf.UploadTTL))
+	if err != nil {
+		u.deleteBlobs(ctx, []string{key})
+		if !errors.Is(err, media.ErrUploadOffsetMismatch) {
+			return nil, err
+		}
+
+		current, findErr := u.findSession(ctx, uploadId)
+		if findErr != nil {
+			return nil, findErr
+		}
+		return toUploadResponse(current), fmt.Errorf("%w: expected offset %d", err, current.Received)
+	}
+
+	return toUploadResponse(updated), nil
+}
+
+// CompleteUpload runs the received bytes through the image or the video
+// pipeline, depending on their content. Calling
+// it again on a completed upload returns the upload unchanged, so a client
+// that lost the response can simply retry. Content the pipeline rejects ends
+// the upload; any other failure leaves it pending for another attempt.
+func (u UploadUseCase) CompleteUpload(ctx context.Context, uploadId string) (*responses.UploadResponse, error) {
+	session, err := u.findSession(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == util.UPLOAD_COMPLETED {
+		return toUploadResponse(session), nil
+	}
+	if session.Status != util.UPLOAD_PENDING || session.Received < session.Size {
+		return nil, fmt.Errorf("%w: received %d of %d bytes", media.ErrUploadIncomplete, session.Received, session.Size)
+	}
+
+	session, err = u.uploadRepository.StartProcessing(ctx, session.UserID.String(), uploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := u.storeMedia(ctx, session)
+	if errors.Is(err, media.ErrUnsupportedMedia) || errors.Is(err, media.ErrMediaTooLarge) {
+		u.deleteBlobs(ctx, session.Chunks)
+		if deleteErr := u.uploadRepository.DeleteSession(ctx, uploadId); deleteErr != nil {
+			u.logger.Error("Failed to remove rejected upload", zap.String("upload_id", uploadId), zap.Error(deleteErr))
+		}
+		return nil, err
+	}
+	if err != nil {
+		u.resetProcessing(ctx, uploadId)
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(u.conf.UploadTTL)
+	finished, err := u.uploadRepository.FinishProcessing(ctx, uploadId, stored, expiresAt)
+	if err != nil || !finished {
+		u.imagePipeline.ReleaseImages(ctx, []map[string]string{stored.Renditions})
+		if err != nil {
+			u.resetProcessing(ctx, uploadId)
+			return nil, err
+		}
+		return nil, media.ErrUploadNotFound
+	}
+	u.deleteBlobs(ctx, session.Chunks)
+
+	session.Status = util.UPLOAD_COMPLETED
+	session.ExpiresAt = expiresAt
+	return toUploadResponse(session), nil
+}
+
+// ClaimUploads takes the media of completed uploads out of their sessions,
+// in the order given. Either every upload is claimed or none is.
+func (u UploadUseCase) ClaimUploads(ctx context.Context, userId string, uploadIds []string) ([]*media.StoredMedia, error) {
+	for _, id := range uploadIds {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("%w: %s", media.ErrUploadNotFound, id)
+		}
+	}
+
+	sessions, err := u.uploadRepository.ClaimSessions(ctx, userId, uploadIds)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*media.StoredMedia, 0, len(sessions))
+	for _, session := range sessions {
+		stored, err := toStoredMedia(session)
+		if err != nil {
+			released := make([]map[string]string, len(claimed))
+			for i, media := range claimed {
+				released[i] = media.Renditions
+			}
+			u.imagePipeline.ReleaseImages(ctx, released)
+			return nil, err
+		}
+		claimed = append(claimed, stored)
+	}
+	return claimed, nil
+}
+
+func (u UploadUseCase) findSession(ctx context.Context, uploadId string) (*entities.UploadSession, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(uploadId); err != nil {
+		return nil, media.ErrUploadNotFound
+	}
+
+	return u.uploadRepository.FindSession(ctx, user.UserId, uploadId)
+}
+
+// storeMedia joins the chunks of session in a temporary file, which the
+// video probe needs, and hands it to the pipeline for its content.
+func (u UploadUseCase) storeMedia(ctx context.Context, session *entities.UploadSession) (*media.StoredMedia, error) {
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	var written int64
+	for _, key := range session.Chunks {
+		blob, err := u.blobStore.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		n, err := io.Copy(file, blob.Body)
+		blob.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		written += n
+	}
+	if written != session.Size {
+		return nil, fmt.Errorf("upload %s has %d of %d bytes stored", session.ID, written, session.Size)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	detected, err := mimetype.DetectReader(file)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(detected.String(), "video/") {
+		return u.videoPipeline.StoreVideo(ctx, file.Name())
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return u.imagePipeline.StoreImage(ctx, file)
+}
+
+func (u UploadUseCase) resetProcessing(ctx context.Context, uploadId string) {
+	if err := u.uploadRepository.ResetProcessing(ctx, uploadId); err != nil {
+		u.logger.Error("Failed to reset upload", zap.String("upload_id", uploadId), zap.Error(err))
+	}
+}
+
+func (u UploadUseCase) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := u.blobStore.Delete(ctx, key); err != nil {
+			u.logger.Error("Failed to remove upload chunk", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+func toStoredMedia(session *entities.UploadSession) (*media.StoredMedia, error) {
+	var renditions map[string]string
+	if err := json.Unmarshal([]byte(session.Renditions), &renditions); err != nil {
+		return nil, err
+	}
+
+	mediaType := session.MediaType
+	if mediaType == "" {
+		mediaType = util.MEDIA_IMAGE
+	}
+	return &media.StoredMedia{
+		Type:       mediaType,
+		Renditions: renditions,
+		Width:      session.Width,
+		Height:     session.Height,
+		DurationMs: session.DurationMs,
+	}, nil
+}
+
+func toUploadResponse(session *entities.UploadSession) *responses.UploadResponse {
+	return &responses.UploadResponse{
+		ID:        session.ID.String(),
+		Size:      session.Size,
+		Received:  session.Received,
+		Status:    session.Status,
+		ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+	}
+}
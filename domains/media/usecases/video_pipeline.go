@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.uber.org/zap"
+)
+
+// allowedVideoTypes maps the containers accepted, detected from content, to
+// the extension the video is stored with.
+var allowedVideoTypes = map[string]string{
+	"video/mp4":       ".mp4",
+	"video/x-m4v":     ".mp4",
+	"video/quicktime": ".mov",
+}
+
+// allowedVideoCodecs and allowedAudioCodecs are the codecs mobile clients
+// play natively. A video without audio has an empty audio codec.
+var (
+	allowedVideoCodecs = map[string]bool{"h264": true, "hevc": true}
+	allowedAudioCodecs = map[string]bool{"": true, "aac": true}
+)
+
+type VideoPipeline struct {
+	blobStore       infrastructures.BlobStore
+	mediaRepository media.MediaRepository
+	probe           infrastructures.MediaProbe
+	images          ImagePipeline
+	conf            config.Media
+	logger          util.Logger
+}
+
+func NewVideoPipeline(blobStore infrastructures.BlobStore, mediaRepository media.MediaRepository, probe infrastructures.MediaProbe, conf config.Media, logger util.Logger) media.VideoPipeline {
+	conf = conf.WithDefaults()
+	return VideoPipeline{
+		blobStore:       blobStore,
+		mediaRepository: mediaRepository,
+		probe:           probe,
+		images: ImagePipeline{
+			blobStore:       blobStore,
+			mediaRepository: mediaRepository,
+			conf:            conf,
+			logger:          logger,
+		},
+		conf:   conf,
+		logger: logger,
+	}
+}
+
+// StoreVideo checks the container and codecs, the duration and the frame
+// size, then stores the video unchanged next to poster renditions taken
+// from one of its frames. Without a probe able to render frames the video
+// is stored without a poster. Like images, videos are keyed by the SHA-256
+// of their bytes and stored once.
+func (v VideoPipeline) StoreVideo(ctx context.Context, path string) (*media.StoredMedia, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > v.conf.MaxVideoBytes {
+		return nil, fmt.Errorf("%w: video limit is %d bytes", media.ErrMediaTooLarge, v.conf.MaxVideoBytes)
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, err
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	stored, found, err := v.mediaRepository.Acquire(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return stored, nil
+	}
+
+	detected, err := mimetype.DetectFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := allowedVideoTypes[detected.String()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", media.ErrUnsupportedMedia, detected.String())
+	}
+
+	probed, err := v.probe.Probe(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", media.ErrUnsupportedMedia, err.Error())
+	}
+	if err := v.validate(probed); err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	key := contentKeyPrefix + hash + "/" + util.RENDITION_VIDEO + ext
+	if err := v.blobStore.Put(ctx, key, file, info.Size(), detected.String()); err != nil {
+		return nil, err
+	}
+
+	renditions, err := v.storePoster(ctx, path, hash, probed.Duration)
+	if err != nil {
+		if !errors.Is(err, infrastructures.ErrPosterUnavailable) {
+			v.logger.Warn("Storing video without a poster frame", zap.String("hash", hash), zap.Error(err))
+		}
+		renditions = make(map[string]string, 1)
+	}
+	renditions[util.RENDITION_VIDEO] = key
+
+	stored = &media.StoredMedia{
+		Type:       util.MEDIA_VIDEO,
+		Renditions: renditions,
+		Width:      probed.Width,
+		Height:     probed.Height,
+		DurationMs: probed.Duration.Milliseconds(),
+	}
+	if err := v.mediaRepository.Create(ctx, hash, stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (v VideoPipeline) validate(probed *infrastructures.ProbeResult) error {
+	if probed.VideoCodec == "" {
+		return fmt.Errorf("%w: no video track", media.ErrUnsupportedMedia)
+	}
+	if !allowedVideoCodecs[probed.VideoCodec] {
+		return fmt.Errorf("%w: video codec %s", media.ErrUnsupportedMedia, probed.VideoCodec)
+	}
+	if !allowedAudioCodecs[probed.AudioCodec] {
+		return fmt.Errorf("%w: audio codec %s", media.ErrUnsupportedMedia, probed.AudioCodec)
+	}
+	if probed.Duration <= 0 || probed.Width <= 0 || probed.Height <= 0 {
+		return fmt.Errorf("%w: video has no duration or size", media.ErrUnsupportedMedia)
+	}
+	if probed.Duration > v.conf.MaxVideoDuration {
+		return fmt.Errorf("%w: video limit is %s", media.ErrMediaTooLarge, v.conf.MaxVideoDuration)
+	}
+	if probed.Width*probed.Height > v.conf.MaxPixels {
+		return fmt.Errorf("%w: limit is %d pixels", media.ErrMediaTooLarge, v.conf.MaxPixels)
+	}
+	return nil
+}
+
+// storePoster renders a frame PosterAt into the video, or halfway through a
+// shorter one, and stores it as the image renditions of the video.
+func (v VideoPipeline) storePoster(ctx context.Context, path string, hash string, duration time.Duration) (map[string]string, error) {
+	at := v.conf.PosterAt
+	if at > duration/2 {
+		at = duration / 2
+	}
+
+	frame, err := v.probe.PosterFrame(ctx, path, at)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := jpeg.Decode(bytes.NewReader(frame))
+	if err != nil {
+		return nil, err
+	}
+
+	return v.images.storeRenditions(ctx, hash, toRGBA(decoded))
+}
@@ -0,0 +1,91 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// UploadCleanupWorker garbage-collects abandoned upload sessions. Chunks of
+// unfinished uploads are deleted, and images of completed uploads no post
+// claimed are released. A session still processing when it expires is left
+// to the completion in progress, which releases its own image.
+type UploadCleanupWorker struct {
+	uploadRepo    media.UploadRepository
+	blobStore     infrastructures.BlobStore
+	imagePipeline media.ImagePipeline
+	conf          config.UploadCleanup
+	logger        util.Logger
+}
+
+func NewUploadCleanupWorker(uploadRepo media.UploadRepository, blobStore infrastructures.BlobStore, imagePipeline media.ImagePipeline, conf config.UploadCleanup, logger util.Logger) *UploadCleanupWorker {
+	return &UploadCleanupWorker{
+		uploadRepo:    uploadRepo,
+		blobStore:     blobStore,
+		imagePipeline: imagePipeline,
+		conf:          conf.WithDefaults(),
+		logger:        logger,
+	}
+}
+
+func (w *UploadCleanupWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *UploadCleanupWorker) sweep(ctx context.Context) {
+	var total int
+	for {
+		sessions, err := w.uploadRepo.DeleteExpired(ctx, time.Now(), w.conf.BatchSize)
+		if err != nil {
+			w.logger.Error("Upload cleanup sweep failed", zap.Error(err))
+			return
+		}
+
+		for _, session := range sessions {
+			for _, key := range session.Chunks {
+				if err := w.blobStore.Delete(ctx, key); err != nil {
+					w.logger.Error("Failed to remove upload chunk", zap.String("key", key), zap.Error(err))
+				}
+			}
+
+			if session.Status != util.UPLOAD_COMPLETED {
+				continue
+			}
+			var renditions map[string]string
+			if err := json.Unmarshal([]byte(session.Renditions), &renditions); err != nil {
+				w.logger.Error("Failed to read abandoned upload",
+					zap.String("upload_id", session.ID.String()),
+					zap.Error(err),
+				)
+				continue
+			}
+			w.imagePipeline.ReleaseImages(ctx, []map[string]string{renditions})
+		}
+
+		total += len(sessions)
+		if len(sessions) < w.conf.BatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Info("Removed abandoned uploads", zap.Int("uploads", total))
+	}
+}
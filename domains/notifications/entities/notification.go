@@ -8,16 +8,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// Notification rows of type NEW_POST are unique per recipient and post, so a
+// fan-out that runs again after a failure cannot notify a follower twice.
 type Notification struct {
 	ID           	uuid.UUID 		`gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
 	SourceUserID 	uuid.UUID 		`gorm:"type:uuid;not null"`
 	SourceUser   	users.User		`gorm:"foreignKey:SourceUserID;constraint:OnDelete:CASCADE"`
-	RecipientID  	uuid.UUID 		`gorm:"type:uuid;not null"`
+	RecipientID  	uuid.UUID 		`gorm:"type:uuid;not null;uniqueIndex:idx_notification_new_post,where:type = 'NEW_POST'"`
 	RecipientUser 	users.User		`gorm:"foreignKey:RecipientID;constraint:OnDelete:CASCADE"`
-	PostID 			uuid.UUID     	`gorm:"type:uuid"`
+	PostID 			uuid.UUID     	`gorm:"type:uuid;uniqueIndex:idx_notification_new_post,where:type = 'NEW_POST'"`
 	Post   			posts.Post    	`gorm:"foreignKey:PostID;references:ID;constraint:OnDelete:CASCADE"`
-	Type         	string    		`gorm:"type:varchar(255)"`
+	Type         	string    		`gorm:"type:varchar(255);uniqueIndex:idx_notification_new_post,where:type = 'NEW_POST'"`
 	Content      	string    		`gorm:"type:varchar(255)"`
+	ReadAt       	*time.Time 		`gorm:"type:timestamp"`
 	CreatedAt    	time.Time 		`gorm:"type:timestamp"`
 	UpdatedAt    	time.Time 		`gorm:"type:timestamp"`
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationDigest records the digest email of one user for one period,
+// such as "DAILY:2026-10-18" or "WEEKLY:2026-W42".
+type NotificationDigest struct {
+	ID                uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID            uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_notification_digest_period"`
+	User              users.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Period            string     `gorm:"type:varchar(32);not null;uniqueIndex:idx_notification_digest_period"`
+	Status            string     `gorm:"type:varchar(20);not null"`
+	NotificationCount int64      `gorm:"not null;default:0"`
+	SentAt            *time.Time `gorm:"type:timestamp"`
+	CreatedAt         time.Time  `gorm:"type:timestamp"`
+}
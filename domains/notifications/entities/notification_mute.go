@@ -0,0 +1,19 @@
+package entities
+
+import (
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationMute silences every notification from SourceUserID, or every
+// notification about PostID, for UserID. Exactly one of the two is set.
+type NotificationMute struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	User         users.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	SourceUserID *uuid.UUID `gorm:"type:uuid"`
+	PostID       *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time  `gorm:"type:timestamp"`
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"bootcamp-content-interaction-service/shared/util"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationPreference struct {
+	UserID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	User            users.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	NewPost         bool       `gorm:"not null"`
+	Like            bool       `gorm:"not null"`
+	Comment         bool       `gorm:"not null"`
+	Reply           bool       `gorm:"not null"`
+	Mention         bool       `gorm:"not null"`
+	DigestFrequency string     `gorm:"type:varchar(10);not null;default:'NONE'"`
+	CreatedAt       time.Time  `gorm:"type:timestamp"`
+	UpdatedAt       time.Time  `gorm:"type:timestamp"`
+}
+
+func DefaultNotificationPreference(userID uuid.UUID) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:  userID,
+		NewPost: true,
+		Like:    true,
+		Comment: true,
+		Reply:   true,
+		Mention: true,
+
+		DigestFrequency: util.DIGEST_NONE,
+	}
+}
+
+func (p *NotificationPreference) Allows(notifType string) bool {
+	switch notifType {
+	case util.NOTIF_POST:
+		return p.NewPost
+	case util.NOTIF_LIKE:
+		return p.Like
+	case util.NOTIF_COMMENT:
+		return p.Comment
+	case util.NOTIF_REPLY:
+		return p.Reply
+	case util.NOTIF_MENTION:
+		return p.Mention
+	default:
+		return true
+	}
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"bootcamp-content-interaction-service/domains/notifications"
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	"bootcamp-content-interaction-service/shared/events"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+)
+
+type NotificationEventHandler struct {
+	fanout     notifications.NotificationFanout
+	dispatcher notifications.NotificationDispatcher
+}
+
+func NewNotificationEventHandler(fanout notifications.NotificationFanout, dispatcher notifications.NotificationDispatcher) *NotificationEventHandler {
+	return &NotificationEventHandler{
+		fanout:     fanout,
+		dispatcher: dispatcher,
+	}
+}
+
+func (h *NotificationEventHandler) Register(bus events.EventBus) {
+	bus.Subscribe(events.POST_CREATED, "notifications", h.OnPostCreated)
+	bus.Subscribe(events.LIKE_ADDED, "notifications", h.OnLikeAdded)
+	bus.Subscribe(events.COMMENT_CREATED, "notifications", h.OnCommentCreated)
+}
+
+func (h *NotificationEventHandler) OnPostCreated(ctx context.Context, event *events.Event) error {
+	var payload events.PostPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	return h.fanout.EnqueueNewPost(ctx, payload.UserID, payload.PostID, payload.Caption)
+}
+
+func (h *NotificationEventHandler) OnLikeAdded(ctx context.Context, event *events.Event) error {
+	var payload events.LikePayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	_, err := h.dispatcher.Dispatch(ctx, []*entities.Notification{{
+		SourceUserID: payload.UserID,
+		RecipientID:  payload.PostOwnerID,
+		PostID:       payload.PostID,
+		Type:         util.NOTIF_LIKE,
+	}})
+	return err
+}
+
+func (h *NotificationEventHandler) OnCommentCreated(ctx context.Context, event *events.Event) error {
+	var payload events.CommentPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	var notifs []*entities.Notification
+	if payload.ReplyToUserID != nil {
+		notifs = append(notifs, &entities.Notification{
+			SourceUserID: payload.UserID,
+			RecipientID:  *payload.ReplyToUserID,
+			PostID:       payload.PostID,
+			Type:         util.NOTIF_REPLY,
+			Content:      payload.Msg,
+		})
+	}
+	if payload.ReplyToUserID == nil || *payload.ReplyToUserID != payload.PostOwnerID {
+		notifs = append(notifs, &entities.Notification{
+			SourceUserID: payload.UserID,
+			RecipientID:  payload.PostOwnerID,
+			PostID:       payload.PostID,
+			Type:         util.NOTIF_COMMENT,
+			Content:      payload.Msg,
+		})
+	}
+
+	_, err := h.dispatcher.Dispatch(ctx, notifs)
+	return err
+}
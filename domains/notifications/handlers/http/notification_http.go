@@ -4,6 +4,7 @@ import (
 	"bootcamp-content-interaction-service/domains/notifications"
 	"bootcamp-content-interaction-service/domains/notifications/models/requests"
 	"bootcamp-content-interaction-service/shared/models/responses"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -22,15 +23,94 @@ func NewNotificationHttp(notifUc notifications.NotificationUseCase) *Notificatio
 
 func (handler *NotificationHttp) ViewAllNotification(c *gin.Context) {
 	ctx := c.Request.Context()
+	var req requests.NotificationPageRequest
 
-	result, err := handler.notifUc.FindAllNotification(ctx)
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
 
-    if err != nil {
-        c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
-        return
-    }
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
 
-    c.JSON(http.StatusOK, result)
+	result, err := handler.notifUc.FindAllNotification(ctx, &req)
+	if errors.Is(err, notifications.ErrInvalidNotification) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *NotificationHttp) DeleteNotification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := handler.notifUc.DeleteNotification(ctx, c.Param("id"))
+	if errors.Is(err, notifications.ErrInvalidNotification) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, notifications.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: "Notification " + c.Param("id") + " deleted"})
+}
+
+func (handler *NotificationHttp) MarkRead(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := handler.notifUc.MarkRead(ctx, c.Param("id"))
+	if errors.Is(err, notifications.ErrInvalidNotification) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, notifications.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: "Notification " + c.Param("id") + " marked as read"})
+}
+
+func (handler *NotificationHttp) MarkAllRead(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	updated, err := handler.notifUc.MarkAllRead(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: gin.H{"updated": updated}})
+}
+
+func (handler *NotificationHttp) DeleteAllNotification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	deleted, err := handler.notifUc.DeleteAllNotification(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: gin.H{"deleted": deleted}})
 }
 
 func (handler *NotificationHttp) CreatePostNotification(c *gin.Context) {
@@ -53,11 +133,96 @@ func (handler *NotificationHttp) CreatePostNotification(c *gin.Context) {
 	}
 
 	result, err := handler.notifUc.NotifyNewPost(ctx, &req)
+	if errors.Is(err, notifications.ErrNotificationSuppressed) {
+		c.JSON(http.StatusOK, responses.BasicResponse{Data: err.Error()})
+		return
+	}
+	if errors.Is(err, notifications.ErrInvalidNotification) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, responses.BasicResponse{
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 	c.JSON(http.StatusOK, result)
-}
\ No newline at end of file
+}
+
+func (handler *NotificationHttp) ViewPreference(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := handler.notifUc.FindPreference(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *NotificationHttp) UpdatePreference(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.UpdateNotificationPreferenceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.notifUc.UpdatePreference(ctx, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *NotificationHttp) ViewAllMute(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := handler.notifUc.FindAllMute(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *NotificationHttp) CreateMute(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.CreateNotificationMuteRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.notifUc.CreateMute(ctx, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (handler *NotificationHttp) DeleteMute(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if err := handler.notifUc.DeleteMute(ctx, c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: "Mute " + c.Param("id") + " deleted"})
+}
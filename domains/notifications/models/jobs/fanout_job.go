@@ -0,0 +1,15 @@
+package jobs
+
+type NewPostFanoutJob struct {
+	SourceUserID string `json:"source_user_id"`
+	PostID       string `json:"post_id"`
+	Content      string `json:"content"`
+}
+
+type DeliverNotificationJob struct {
+	SourceUserID string   `json:"source_user_id"`
+	PostID       string   `json:"post_id"`
+	Type         string   `json:"type"`
+	Content      string   `json:"content"`
+	RecipientIDs []string `json:"recipient_ids"`
+}
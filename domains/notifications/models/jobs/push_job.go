@@ -0,0 +1,13 @@
+package jobs
+
+type SendPushJob struct {
+	Notifications []PushNotificationItem `json:"notifications"`
+}
+
+type PushNotificationItem struct {
+	NotificationID string `json:"notification_id"`
+	RecipientID    string `json:"recipient_id"`
+	PostID         string `json:"post_id"`
+	Type           string `json:"type"`
+	Content        string `json:"content"`
+}
@@ -0,0 +1,6 @@
+package requests
+
+type NotificationPageRequest struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,16 @@
+package requests
+
+type UpdateNotificationPreferenceRequest struct {
+	NewPost *bool `json:"new_post"`
+	Like    *bool `json:"like"`
+	Comment *bool `json:"comment"`
+	Reply   *bool `json:"reply"`
+	Mention *bool `json:"mention"`
+
+	DigestFrequency *string `json:"digest_frequency" validate:"omitempty,oneof=NONE DAILY WEEKLY"`
+}
+
+type CreateNotificationMuteRequest struct {
+	SourceUserID string `json:"source_user_id"`
+	PostID       string `json:"post_id"`
+}
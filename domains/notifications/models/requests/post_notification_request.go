@@ -1,8 +1,8 @@
 package requests
 
 type PostNotificationRequest struct {
-	SourceUserID string `json:"source_user_id" validate:"required"`
-    RecipientID  string `json:"recipient_id" validate:"required"`
-    PostID       string `json:"post_id" validate:"required"`
+	SourceUserID string `json:"source_user_id" validate:"required,uuid"`
+    RecipientID  string `json:"recipient_id" validate:"required,uuid"`
+    PostID       string `json:"post_id" validate:"required,uuid"`
     Content      string `json:"content"`
 }
\ No newline at end of file
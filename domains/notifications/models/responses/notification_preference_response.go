@@ -0,0 +1,18 @@
+package responses
+
+type NotificationPreferenceResponse struct {
+	NewPost bool `json:"new_post"`
+	Like    bool `json:"like"`
+	Comment bool `json:"comment"`
+	Reply   bool `json:"reply"`
+	Mention bool `json:"mention"`
+
+	DigestFrequency string `json:"digest_frequency"`
+}
+
+type NotificationMuteResponse struct {
+	ID           string `json:"id"`
+	SourceUserID string `json:"source_user_id,omitempty"`
+	PostID       string `json:"post_id,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
@@ -5,5 +5,13 @@ type PostNotificationResponse struct {
 	SourceUserID string `json:"source_user_id"`
     RecipientID  string `json:"recipient_id"`
     PostID       string `json:"post_id"`
+    Type         string `json:"type"`
     Content      string `json:"content"`
+    ReadAt       string `json:"read_at,omitempty"`
+    CreatedAt    string `json:"created_at"`
+}
+
+type NotificationPageResponse struct {
+	Items      []*PostNotificationResponse `json:"items"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
 }
\ No newline at end of file
@@ -4,15 +4,92 @@ import (
 	"bootcamp-content-interaction-service/domains/notifications/entities"
 	"bootcamp-content-interaction-service/domains/notifications/models/requests"
 	"bootcamp-content-interaction-service/domains/notifications/models/responses"
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"bootcamp-content-interaction-service/shared/util"
 	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotificationSuppressed = errors.New("notification suppressed by recipient preferences")
+	ErrInvalidNotification    = errors.New("invalid notification request")
+	ErrNotificationNotFound   = errors.New("notification not found")
 )
 
 type NotificationUseCase interface {
 	NotifyNewPost(ctx context.Context, request *requests.PostNotificationRequest) (*responses.PostNotificationResponse, error)
-	FindAllNotification(ctx context.Context) ([]*responses.PostNotificationResponse, error)
+	FindAllNotification(ctx context.Context, request *requests.NotificationPageRequest) (*responses.NotificationPageResponse, error)
+	DeleteNotification(ctx context.Context, notificationId string) error
+	DeleteAllNotification(ctx context.Context) (int64, error)
+	MarkRead(ctx context.Context, notificationId string) error
+	MarkAllRead(ctx context.Context) (int64, error)
+	FindPreference(ctx context.Context) (*responses.NotificationPreferenceResponse, error)
+	UpdatePreference(ctx context.Context, request *requests.UpdateNotificationPreferenceRequest) (*responses.NotificationPreferenceResponse, error)
+	FindAllMute(ctx context.Context) ([]*responses.NotificationMuteResponse, error)
+	CreateMute(ctx context.Context, request *requests.CreateNotificationMuteRequest) (*responses.NotificationMuteResponse, error)
+	DeleteMute(ctx context.Context, muteId string) error
 }
 
 type NotificationRepository interface {
 	SaveNotification(ctx context.Context, notif *entities.Notification) (*entities.Notification, error)
-	FindAll(ctx context.Context, recipientId string) ([]*entities.Notification, error)
-}
\ No newline at end of file
+	SaveNotifications(ctx context.Context, notifs []*entities.Notification) ([]*entities.Notification, error)
+	FindPage(ctx context.Context, recipientId string, cursor *util.Cursor, limit int) ([]*entities.Notification, bool, error)
+	DeleteNotification(ctx context.Context, recipientId string, id string) error
+	DeleteAllNotification(ctx context.Context, recipientId string) (int64, error)
+	DeleteOlderThan(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
+	MarkRead(ctx context.Context, recipientId string, id string) error
+	MarkAllRead(ctx context.Context, recipientId string) (int64, error)
+}
+
+// NotificationDigestRepository backs the email digest. A digest row is
+// claimed per (user, period) before anything is sent, which is what keeps
+// a period from being mailed twice.
+type NotificationDigestRepository interface {
+	FindDigestRecipients(ctx context.Context, frequency string, from time.Time, to time.Time, period string, after uuid.UUID, limit int) ([]*users.User, error)
+	FindUnread(ctx context.Context, recipientId uuid.UUID, from time.Time, to time.Time, limit int) ([]*entities.Notification, int64, error)
+	ClaimDigest(ctx context.Context, userId uuid.UUID, period string) (bool, error)
+	CompleteDigest(ctx context.Context, userId uuid.UUID, period string, count int64) error
+	ReleaseDigest(ctx context.Context, userId uuid.UUID, period string) error
+}
+
+type NotificationPreferenceRepository interface {
+	FindPreference(ctx context.Context, userId string) (*entities.NotificationPreference, error)
+	FindPreferences(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]*entities.NotificationPreference, error)
+	SavePreference(ctx context.Context, pref *entities.NotificationPreference) (*entities.NotificationPreference, error)
+	FindMutes(ctx context.Context, userId string) ([]*entities.NotificationMute, error)
+	FindMutedRecipients(ctx context.Context, recipientIds []uuid.UUID, sourceUserId uuid.UUID, postId uuid.UUID) (map[uuid.UUID]bool, error)
+	SaveMute(ctx context.Context, mute *entities.NotificationMute) (*entities.NotificationMute, error)
+	DeleteMute(ctx context.Context, userId string, muteId string) error
+}
+
+// NotificationDispatcher is the single entry point for producing
+// notifications. It drops anything the recipient has opted out of before
+// persisting the rest.
+type NotificationDispatcher interface {
+	Dispatch(ctx context.Context, notifs []*entities.Notification) ([]*entities.Notification, error)
+}
+
+// NotificationFanout hands new-post notifications to the background workers
+// so the request that created the post does not wait on the user graph.
+type NotificationFanout interface {
+	EnqueueNewPost(ctx context.Context, sourceUserId uuid.UUID, postId uuid.UUID, content string) error
+}
+
+// NotificationPusher sends saved notifications to the recipients' devices
+// in the background.
+type NotificationPusher interface {
+	EnqueuePush(ctx context.Context, notifs []*entities.Notification) error
+}
+
+// RelationSource reports which recipients must not hear from a user because
+// of a block or mute between them.
+type RelationSource interface {
+	FindHidingRecipients(ctx context.Context, sourceUserId uuid.UUID, recipientIds []uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+type FollowerSource interface {
+	GetFollowersPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error)
+}
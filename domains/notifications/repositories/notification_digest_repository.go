@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/notifications"
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationDigestRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+}
+
+func NewNotificationDigestRepository(db infrastructures.Database, logger util.Logger) notifications.NotificationDigestRepository {
+	return NotificationDigestRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// FindDigestRecipients returns users after the given id, in id order, who are
+// on the given frequency, have unread notifications in [from, to) and no
+// digest row for period yet. Users without a stored preference get no
+// digest; it is opt-in.
+func (n NotificationDigestRepository) FindDigestRecipients(ctx context.Context, frequency string, from time.Time, to time.Time, period string, after uuid.UUID, limit int) ([]*users.User, error) {
+	var recipients []*users.User
+
+	result := n.db.GetInstance().WithContext(ctx).
+		Model(&users.User{}).
+		Select("users.id", "users.name", "users.username", "users.email").
+		Joins("LEFT JOIN notification_preferences ON notification_preferences.user_id = users.id").
+		Where("COALESCE(notification_preferences.digest_frequency, ?) = ?", util.DIGEST_NONE, frequency).
+		Where("users.id > ? AND users.email <> ''", after).
+		Where(`EXISTS (SELECT 1 FROM notifications WHERE notifications.recipient_id = users.id
+			AND notifications.read_at IS NULL AND notifications.created_at >= ? AND notifications.created_at < ?)`, from, to).
+		Where("NOT EXISTS (SELECT 1 FROM notification_digests WHERE notification_digests.user_id = users.id AND notification_digests.period = ?)", period).
+		Order("users.id ASC").
+		Limit(limit).
+		Find(&recipients)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return recipients, nil
+}
+
+func (n NotificationDigestRepository) FindUnread(ctx context.Context, recipientID uuid.UUID, from time.Time, to time.Time, limit int) ([]*entities.Notification, int64, error) {
+	unreadIn := func() *gorm.DB {
+		return n.db.GetInstance().WithContext(ctx).
+			Model(&entities.Notification{}).
+			Where("recipient_id = ? AND read_at IS NULL AND created_at >= ? AND created_at < ?", recipientID, from, to)
+	}
+
+	var total int64
+	if err := unreadIn().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var unread []*entities.Notification
+	result := unreadIn().Preload("SourceUser").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&unread)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return unread, total, nil
+}
+
+// ClaimDigest reports whether this caller now owns the digest of userID for
+// period. A digest that crashed mid-send stays claimed and is never retried,
+// which errs on the side of not mailing anyone twice.
+func (n NotificationDigestRepository) ClaimDigest(ctx context.Context, userID uuid.UUID, period string) (bool, error) {
+	digest := &entities.NotificationDigest{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Period:    period,
+		Status:    util.DIGEST_SENDING,
+		CreatedAt: time.Now(),
+	}
+
+	result := n.db.GetInstance().WithContext(ctx).
+		Omit("User").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(digest)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (n NotificationDigestRepository) CompleteDigest(ctx context.Context, userID uuid.UUID, period string, count int64) error {
+	now := time.Now()
+	result := n.db.GetInstance().WithContext(ctx).
+		Model(&entities.NotificationDigest{}).
+		Where("user_id = ? AND period = ?", userID, period).
+		Updates(map[string]interface{}{
+			"status":             util.DIGEST_SENT,
+			"notification_count": count,
+			"sent_at":            now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	n.logger.Info("Notification digest sent",
+		zap.String("user_id", userID.String()),
+		zap.String("period", period),
+		zap.Int64("count", count),
+	)
+
+	return nil
+}
+
+// ReleaseDigest drops a claim whose send failed, so the next run retries it.
+func (n NotificationDigestRepository) ReleaseDigest(ctx context.Context, userID uuid.UUID, period string) error {
+	return n.db.GetInstance().WithContext(ctx).
+		Where("user_id = ? AND period = ? AND status = ?", userID, period, util.DIGEST_SENDING).
+		Delete(&entities.NotificationDigest{}).Error
+}
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/notifications"
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationPreferenceRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+}
+
+func NewNotificationPreferenceRepository(db infrastructures.Database, logger util.Logger) notifications.NotificationPreferenceRepository {
+	return NotificationPreferenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (n NotificationPreferenceRepository) FindPreference(ctx context.Context, userID string) (*entities.NotificationPreference, error) {
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+
+	var pref entities.NotificationPreference
+	result := n.db.GetInstance().WithContext(ctx).Where("user_id = ?", parsedID).First(&pref)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return entities.DefaultNotificationPreference(parsedID), nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &pref, nil
+}
+
+func (n NotificationPreferenceRepository) FindPreferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*entities.NotificationPreference, error) {
+	prefs := make(map[uuid.UUID]*entities.NotificationPreference, len(userIDs))
+	if len(userIDs) == 0 {
+		return prefs, nil
+	}
+
+	var stored []*entities.NotificationPreference
+	result := n.db.GetInstance().WithContext(ctx).Where("user_id IN ?", userIDs).Find(&stored)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, pref := range stored {
+		prefs[pref.UserID] = pref
+	}
+	for _, userID := range userIDs {
+		if _, ok := prefs[userID]; !ok {
+			prefs[userID] = entities.DefaultNotificationPreference(userID)
+		}
+	}
+
+	return prefs, nil
+}
+
+func (n NotificationPreferenceRepository) SavePreference(ctx context.Context, pref *entities.NotificationPreference) (*entities.NotificationPreference, error) {
+	now := time.Now()
+	if pref.CreatedAt.IsZero() {
+		pref.CreatedAt = now
+	}
+	pref.UpdatedAt = now
+
+	result := n.db.GetInstance().WithContext(ctx).
+		Omit("User").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"new_post", "like", "comment", "reply", "mention", "digest_frequency", "updated_at"}),
+		}).
+		Create(pref)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	n.logger.Info("Notification preference saved",
+		zap.String("user_id", pref.UserID.String()),
+	)
+
+	return pref, nil
+}
+
+func (n NotificationPreferenceRepository) FindMutes(ctx context.Context, userID string) ([]*entities.NotificationMute, error) {
+	var mutes []*entities.NotificationMute
+
+	result := n.db.GetInstance().WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&mutes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return mutes, nil
+}
+
+func (n NotificationPreferenceRepository) FindMutedRecipients(ctx context.Context, recipientIDs []uuid.UUID, sourceUserID uuid.UUID, postID uuid.UUID) (map[uuid.UUID]bool, error) {
+	muted := make(map[uuid.UUID]bool)
+	if len(recipientIDs) == 0 {
+		return muted, nil
+	}
+
+	var userIDs []uuid.UUID
+	result := n.db.GetInstance().WithContext(ctx).
+		Model(&entities.NotificationMute{}).
+		Where("user_id IN ?", recipientIDs).
+		Where("source_user_id = ? OR post_id = ?", sourceUserID, postID).
+		Distinct().
+		Pluck("user_id", &userIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, userID := range userIDs {
+		muted[userID] = true
+	}
+
+	return muted, nil
+}
+
+func (n NotificationPreferenceRepository) SaveMute(ctx context.Context, mute *entities.NotificationMute) (*entities.NotificationMute, error) {
+	var existing entities.NotificationMute
+
+	query := n.db.GetInstance().WithContext(ctx).Where("user_id = ?", mute.UserID)
+	if mute.SourceUserID != nil {
+		query = query.Where("source_user_id = ?", *mute.SourceUserID)
+	} else {
+		query = query.Where("post_id = ?", *mute.PostID)
+	}
+
+	err := query.First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	mute.ID = uuid.New()
+	mute.CreatedAt = time.Now()
+
+	result := n.db.GetInstance().WithContext(ctx).Omit("User").Create(mute)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	n.logger.Info("Notification mute saved",
+		zap.String("user_id", mute.UserID.String()),
+		zap.String("mute_id", mute.ID.String()),
+	)
+
+	return mute, nil
+}
+
+func (n NotificationPreferenceRepository) DeleteMute(ctx context.Context, userID string, muteID string) error {
+	result := n.db.GetInstance().WithContext(ctx).
+		Where("id = ? AND user_id = ?", muteID, userID).
+		Delete(&entities.NotificationMute{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("mute not found")
+	}
+
+	return nil
+}
@@ -7,13 +7,27 @@ import (
 	"bootcamp-content-interaction-service/shared/util"
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const (
+	inboxCacheSize = 100
+	inboxCacheTTL  = 10 * time.Minute
+	// inboxVersionTTL outlives any warm-up in flight, so a version never
+	// expires between the read it guards and the write.
+	inboxVersionTTL = 24 * time.Hour
+)
+
+// errInboxChanged aborts a warm-up that raced with an invalidation.
+var errInboxChanged = errors.New("inbox changed while warming")
+
 type NotificationRepository struct {
 	db          infrastructures.Database
 	redisClient *redis.Client
@@ -28,84 +42,379 @@ func NewNotificationRepository(db infrastructures.Database, redisClient *redis.C
 	}
 }
 
-func (n NotificationRepository) FindAll(ctx context.Context, recipientID string) ([]*entities.Notification, error) {
-	key :="post_notifications:" + recipientID
-	var notifications []*entities.Notification
+func inboxKey(recipientID string) string {
+	return "post_notifications:" + recipientID
+}
 
-	//get from redis
-	cached, err := n.redisClient.LRange(ctx, key, 0, -1).Result()
-	if err == nil && len(cached) > 0 {
-		n.logger.Info("Cache hit - returning notifications from redis",
-			zap.String("cache_key", key),
-		)
-		for _, jsonItem := range cached {
-			var notification entities.Notification
-			if err := json.Unmarshal([]byte(jsonItem), &notification); err == nil {
-				notifications = append(notifications, &notification)
+// inboxCompleteKey marks an inbox list that holds every notification of the
+// recipient, so a short read from it is the real end of the inbox rather
+// than the end of the cached window.
+func inboxCompleteKey(recipientID string) string {
+	return "post_notifications:" + recipientID + ":complete"
+}
+
+// inboxVersionKey counts the invalidations of an inbox. A warm-up only
+// writes the rows it read if the count has not moved since, so a change
+// that lands in between is never covered up by the older rows.
+func inboxVersionKey(recipientID string) string {
+	return "post_notifications:" + recipientID + ":version"
+}
+
+// FindPage returns up to limit notifications after cursor, newest first, and
+// whether more exist. The Redis list caches the newest inboxCacheSize rows in
+// exactly the database order, and is only used when it can answer the whole
+// page, so both paths always produce the same page.
+func (n NotificationRepository) FindPage(ctx context.Context, recipientID string, cursor *util.Cursor, limit int) ([]*entities.Notification, bool, error) {
+	cached, complete, ok := n.readInbox(ctx, recipientID)
+	if !ok && cursor == nil {
+		cached, complete, ok = n.warmInbox(ctx, recipientID)
+	}
+
+	if ok {
+		var page []*entities.Notification
+		for _, notification := range cached {
+			if cursor.After(notification.CreatedAt, notification.ID) {
+				page = append(page, notification)
 			}
+			if len(page) > limit {
+				break
+			}
+		}
+
+		if len(page) > limit {
+			n.logger.Info("Cache hit - returning notifications from redis",
+				zap.String("cache_key", inboxKey(recipientID)),
+			)
+			return page[:limit], true, nil
+		}
+		if complete {
+			n.logger.Info("Cache hit - returning notifications from redis",
+				zap.String("cache_key", inboxKey(recipientID)),
+			)
+			return page, false, nil
+		}
+	}
+
+	var notifications []*entities.Notification
+	query := n.db.GetInstance().WithContext(ctx).Where("recipient_id = ?", recipientID)
+	if cursor != nil {
+		query = query.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+	result := query.Order("created_at DESC, id DESC").Limit(limit + 1).Find(&notifications)
+	n.logger.Info("Get notification page from DB",
+		zap.String("recipient_id", recipientID),
+	)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+
+	if len(notifications) > limit {
+		return notifications[:limit], true, nil
+	}
+	return notifications, false, nil
+}
+
+func (n NotificationRepository) readInbox(ctx context.Context, recipientID string) ([]*entities.Notification, bool, bool) {
+	pipe := n.redisClient.Pipeline()
+	listCmd := pipe.LRange(ctx, inboxKey(recipientID), 0, -1)
+	completeCmd := pipe.Exists(ctx, inboxCompleteKey(recipientID))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, false, false
+	}
+
+	cached := listCmd.Val()
+	complete := completeCmd.Val() == 1
+	if len(cached) == 0 && !complete {
+		return nil, false, false
+	}
+
+	notifications := make([]*entities.Notification, 0, len(cached))
+	for _, jsonItem := range cached {
+		var notification entities.Notification
+		if err := json.Unmarshal([]byte(jsonItem), &notification); err != nil {
+			return nil, false, false
 		}
-		return notifications, nil
+		notifications = append(notifications, &notification)
 	}
 
-	//else , get from db
-	result := n.db.GetInstance().WithContext(ctx).Where("recipient_id = ?", recipientID).Order("created_at DESC").Find(&notifications)
+	return notifications, complete, true
+}
+
+func (n NotificationRepository) warmInbox(ctx context.Context, recipientID string) ([]*entities.Notification, bool, bool) {
+	versionKey := inboxVersionKey(recipientID)
+	version, err := n.redisClient.Get(ctx, versionKey).Result()
+	cacheable := err == nil || errors.Is(err, redis.Nil)
+
+	var notifications []*entities.Notification
+	result := n.db.GetInstance().WithContext(ctx).
+		Where("recipient_id = ?", recipientID).
+		Order("created_at DESC, id DESC").
+		Limit(inboxCacheSize + 1).
+		Find(&notifications)
 	n.logger.Info("Get all data from DB")
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, false, false
 	}
 
-	// set in redis
-	for _, notification := range notifications {
-		notifJSON, _ := json.Marshal(notification)
-		_ = n.redisClient.LPush(ctx, key, notifJSON).Err()
-		n.logger.Info("Set notif in cache",
-			zap.String("notif_json", string(notifJSON)),
+	complete := len(notifications) <= inboxCacheSize
+	if !complete {
+		notifications = notifications[:inboxCacheSize]
+	}
+
+	if !cacheable {
+		return notifications, complete, true
+	}
+
+	err = n.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, versionKey).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if current != version {
+			return errInboxChanged
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, inboxKey(recipientID), inboxCompleteKey(recipientID))
+			for _, notification := range notifications {
+				notifJSON, _ := json.Marshal(notification)
+				pipe.RPush(ctx, inboxKey(recipientID), notifJSON)
+			}
+			pipe.Expire(ctx, inboxKey(recipientID), inboxCacheTTL)
+			if complete {
+				pipe.Set(ctx, inboxCompleteKey(recipientID), 1, inboxCacheTTL)
+			}
+			return nil
+		})
+		return err
+	}, versionKey)
+	if errors.Is(err, errInboxChanged) || errors.Is(err, redis.TxFailedErr) {
+		n.logger.Info("Skipped warming notification cache after a concurrent change",
+			zap.String("recipient_id", recipientID),
 		)
+	} else if err != nil {
+		n.logger.Warn("Failed to warm notification cache", zap.Error(err))
+	}
+
+	return notifications, complete, true
+}
+
+func (n NotificationRepository) invalidateInboxes(ctx context.Context, recipientIDs ...string) {
+	if len(recipientIDs) == 0 {
+		return
+	}
+
+	// Bumping the version stops warm-ups that read before this change from
+	// writing their rows once the lists are gone.
+	pipe := n.redisClient.TxPipeline()
+	keys := make([]string, 0, len(recipientIDs)*2)
+	for _, recipientID := range recipientIDs {
+		keys = append(keys, inboxKey(recipientID), inboxCompleteKey(recipientID))
+		pipe.Incr(ctx, inboxVersionKey(recipientID))
+		pipe.Expire(ctx, inboxVersionKey(recipientID), inboxVersionTTL)
+	}
+	pipe.Del(ctx, keys...)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		n.logger.Warn("Failed to invalidate notification cache", zap.Error(err))
 	}
-	_ = n.redisClient.LTrim(ctx, key, 0, 99)
-	_ = n.redisClient.Expire(ctx, key,  7*24*time.Hour)
-	return notifications, nil
 }
 
 func (n NotificationRepository) SaveNotification(ctx context.Context, notif *entities.Notification) (*entities.Notification, error) {
+	saved, err := n.SaveNotifications(ctx, []*entities.Notification{notif})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved[0], nil
+}
 
-	notifmodel := &entities.Notification{
-		ID:           uuid.New(),
-		SourceUserID: notif.SourceUserID,
-		RecipientID:  notif.RecipientID,
-		PostID:       notif.PostID,
-		Type:         notif.Type,
-		Content:      notif.Content,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+func (n NotificationRepository) SaveNotifications(ctx context.Context, notifs []*entities.Notification) ([]*entities.Notification, error) {
+	if len(notifs) == 0 {
+		return nil, nil
 	}
 
-	result := n.db.GetInstance().WithContext(ctx).Create(notifmodel)
+	// Postgres keeps microseconds and no zone, so the cached copy has to be
+	// built from the same value the database will return.
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	notifModels := make([]*entities.Notification, 0, len(notifs))
+	recipientIDs := make([]string, 0, len(notifs))
+	for _, notif := range notifs {
+		content := []rune(notif.Content)
+		if len(content) > 255 {
+			content = content[:255]
+		}
+
+		notifModels = append(notifModels, &entities.Notification{
+			ID:           uuid.New(),
+			SourceUserID: notif.SourceUserID,
+			RecipientID:  notif.RecipientID,
+			PostID:       notif.PostID,
+			Type:         notif.Type,
+			Content:      string(content),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+		recipientIDs = append(recipientIDs, notif.RecipientID.String())
+	}
+
+	// A NEW_POST row that already exists is skipped rather than failing the
+	// batch, and only the rows actually inserted are returned.
+	result := n.db.GetInstance().WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(notifModels, 500)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	if hasType(notifModels, util.NOTIF_POST) {
+		inserted, err := n.findInserted(ctx, notifModels)
+		if err != nil {
+			return nil, err
+		}
+		notifModels = inserted
+	}
 
-	n.logger.Info("Notification saved to DB",
-		zap.String("notification_id", notifmodel.ID.String()),
-		zap.String("recipient_id", notifmodel.RecipientID.String()),
+	n.logger.Info("Notifications saved to DB",
+		zap.Int("count", len(notifModels)),
 	)
 
-	inboxKey := "post_notifications:" + notifmodel.RecipientID.String()
-	notifJSON, err := json.Marshal(notifmodel)
+	n.invalidateInboxes(ctx, recipientIDs...)
+
+	return notifModels, nil
+}
+
+func hasType(notifs []*entities.Notification, notifType string) bool {
+	for _, notif := range notifs {
+		if notif.Type == notifType {
+			return true
+		}
+	}
+	return false
+}
+
+// findInserted keeps the notifications whose rows were written by this insert.
+func (n NotificationRepository) findInserted(ctx context.Context, notifs []*entities.Notification) ([]*entities.Notification, error) {
+	ids := make([]uuid.UUID, 0, len(notifs))
+	for _, notif := range notifs {
+		ids = append(ids, notif.ID)
+	}
+
+	var found []uuid.UUID
+	err := n.db.GetInstance().WithContext(ctx).
+		Model(&entities.Notification{}).
+		Where("id IN ?", ids).
+		Pluck("id", &found).Error
 	if err != nil {
-		n.logger.Warn("Redis marshal failed", zap.Error(err))
-		return notifmodel, nil
+		return nil, err
 	}
 
-	if err := n.redisClient.LPush(ctx, inboxKey, notifJSON).Err(); err != nil {
-		n.logger.Warn("Redis LPUSH failed", zap.Error(err))
-	} else {
-		_ = n.redisClient.Expire(ctx, inboxKey, 7*24*time.Hour).Err()
-		n.logger.Info("Notification stored in Redis",
-			zap.String("redis_key", inboxKey),
-			zap.String("recipient_id", notifmodel.RecipientID.String()),
-		)
+	exists := make(map[uuid.UUID]bool, len(found))
+	for _, id := range found {
+		exists[id] = true
+	}
+
+	inserted := make([]*entities.Notification, 0, len(found))
+	for _, notif := range notifs {
+		if exists[notif.ID] {
+			inserted = append(inserted, notif)
+		}
+	}
+	return inserted, nil
+}
+
+func (n NotificationRepository) DeleteNotification(ctx context.Context, recipientID string, id string) error {
+	result := n.db.GetInstance().WithContext(ctx).
+		Where("id = ? AND recipient_id = ?", id, recipientID).
+		Delete(&entities.Notification{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return notifications.ErrNotificationNotFound
 	}
 
-	return notifmodel, nil
+	n.invalidateInboxes(ctx, recipientID)
+
+	return nil
+}
+
+func (n NotificationRepository) DeleteAllNotification(ctx context.Context, recipientID string) (int64, error) {
+	result := n.db.GetInstance().WithContext(ctx).
+		Where("recipient_id = ?", recipientID).
+		Delete(&entities.Notification{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	n.invalidateInboxes(ctx, recipientID)
+
+	return result.RowsAffected, nil
+}
+
+// DeleteOlderThan removes up to batchSize notifications created before cutoff
+// and drops the cached inbox of every recipient that lost one.
+func (n NotificationRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	var expired []*entities.Notification
+	result := n.db.GetInstance().WithContext(ctx).
+		Select("id", "recipient_id").
+		Where("created_at < ?", cutoff.UTC()).
+		Limit(batchSize).
+		Find(&expired)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(expired))
+	recipients := make(map[string]struct{})
+	for _, notification := range expired {
+		ids = append(ids, notification.ID)
+		recipients[notification.RecipientID.String()] = struct{}{}
+	}
+
+	result = n.db.GetInstance().WithContext(ctx).Where("id IN ?", ids).Delete(&entities.Notification{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	recipientIDs := make([]string, 0, len(recipients))
+	for recipientID := range recipients {
+		recipientIDs = append(recipientIDs, recipientID)
+	}
+	n.invalidateInboxes(ctx, recipientIDs...)
+
+	return result.RowsAffected, nil
+}
+
+func (n NotificationRepository) MarkRead(ctx context.Context, recipientID string, id string) error {
+	result := n.db.GetInstance().WithContext(ctx).
+		Model(&entities.Notification{}).
+		Where("id = ? AND recipient_id = ?", id, recipientID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now().UTC().Truncate(time.Microsecond)))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return notifications.ErrNotificationNotFound
+	}
+
+	n.invalidateInboxes(ctx, recipientID)
+
+	return nil
+}
+
+func (n NotificationRepository) MarkAllRead(ctx context.Context, recipientID string) (int64, error) {
+	result := n.db.GetInstance().WithContext(ctx).
+		Model(&entities.Notification{}).
+		Where("recipient_id = ? AND read_at IS NULL", recipientID).
+		Update("read_at", time.Now().UTC().Truncate(time.Microsecond))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	n.invalidateInboxes(ctx, recipientID)
+
+	return result.RowsAffected, nil
 }
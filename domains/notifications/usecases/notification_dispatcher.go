@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/notifications"
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type NotificationDispatcher struct {
+	notifRepo notifications.NotificationRepository
+	prefRepo  notifications.NotificationPreferenceRepository
+	pusher    notifications.NotificationPusher
+	relations notifications.RelationSource
+	logger    util.Logger
+}
+
+func NewNotificationDispatcher(notifRepo notifications.NotificationRepository, prefRepo notifications.NotificationPreferenceRepository, pusher notifications.NotificationPusher, relations notifications.RelationSource, logger util.Logger) notifications.NotificationDispatcher {
+	return NotificationDispatcher{
+		notifRepo: notifRepo,
+		prefRepo:  prefRepo,
+		pusher:    pusher,
+		relations: relations,
+		logger:    logger,
+	}
+}
+
+func (d NotificationDispatcher) Dispatch(ctx context.Context, notifs []*entities.Notification) ([]*entities.Notification, error) {
+	allowed, err := d.filter(ctx, notifs)
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info("Dispatching notifications",
+		zap.Int("requested", len(notifs)),
+		zap.Int("allowed", len(allowed)),
+	)
+	if len(allowed) == 0 {
+		return nil, nil
+	}
+
+	saved, err := d.notifRepo.SaveNotifications(ctx, allowed)
+	if err != nil {
+		return nil, err
+	}
+
+	// The notifications are stored at this point; failing the dispatch over
+	// a push would only make the caller retry and store them twice.
+	if err := d.pusher.EnqueuePush(ctx, saved); err != nil {
+		d.logger.Warn("Failed to enqueue push notifications", zap.Error(err))
+	}
+
+	return saved, nil
+}
+
+type muteScope struct {
+	sourceUserID uuid.UUID
+	postID       uuid.UUID
+}
+
+func (d NotificationDispatcher) filter(ctx context.Context, notifs []*entities.Notification) ([]*entities.Notification, error) {
+	var recipientIDs []uuid.UUID
+	scopes := make(map[muteScope][]uuid.UUID)
+	bySource := make(map[uuid.UUID][]uuid.UUID)
+	for _, notif := range notifs {
+		if notif.RecipientID == notif.SourceUserID {
+			continue
+		}
+		recipientIDs = append(recipientIDs, notif.RecipientID)
+		scope := muteScope{sourceUserID: notif.SourceUserID, postID: notif.PostID}
+		scopes[scope] = append(scopes[scope], notif.RecipientID)
+		bySource[notif.SourceUserID] = append(bySource[notif.SourceUserID], notif.RecipientID)
+	}
+
+	hiding := make(map[uuid.UUID]map[uuid.UUID]bool, len(bySource))
+	for sourceUserID, recipients := range bySource {
+		hidingRecipients, err := d.relations.FindHidingRecipients(ctx, sourceUserID, recipients)
+		if err != nil {
+			return nil, err
+		}
+		hiding[sourceUserID] = hidingRecipients
+	}
+
+	prefs, err := d.prefRepo.FindPreferences(ctx, recipientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	muted := make(map[muteScope]map[uuid.UUID]bool, len(scopes))
+	for scope, recipients := range scopes {
+		mutedRecipients, err := d.prefRepo.FindMutedRecipients(ctx, recipients, scope.sourceUserID, scope.postID)
+		if err != nil {
+			return nil, err
+		}
+		muted[scope] = mutedRecipients
+	}
+
+	var allowed []*entities.Notification
+	for _, notif := range notifs {
+		if notif.RecipientID == notif.SourceUserID {
+			continue
+		}
+		if pref, ok := prefs[notif.RecipientID]; ok && !pref.Allows(notif.Type) {
+			continue
+		}
+		if muted[muteScope{sourceUserID: notif.SourceUserID, postID: notif.PostID}][notif.RecipientID] {
+			continue
+		}
+		if hiding[notif.SourceUserID][notif.RecipientID] {
+			continue
+		}
+		allowed = append(allowed, notif)
+	}
+
+	return allowed, nil
+}
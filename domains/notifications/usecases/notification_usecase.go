@@ -7,68 +7,302 @@ import (
 	"bootcamp-content-interaction-service/domains/notifications/models/responses"
 	"bootcamp-content-interaction-service/shared/util"
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type NotificationUseCase struct {
-	notifRepo notifications.NotificationRepository
+	notifRepo  notifications.NotificationRepository
+	prefRepo   notifications.NotificationPreferenceRepository
+	dispatcher notifications.NotificationDispatcher
 }
 
-func NewNotificationUseCase(notifRepo notifications.NotificationRepository) notifications.NotificationUseCase {
+func NewNotificationUseCase(notifRepo notifications.NotificationRepository, prefRepo notifications.NotificationPreferenceRepository, dispatcher notifications.NotificationDispatcher) notifications.NotificationUseCase {
 	return NotificationUseCase{
-		notifRepo: notifRepo,
+		notifRepo:  notifRepo,
+		prefRepo:   prefRepo,
+		dispatcher: dispatcher,
 	}
 }
 
-func (n NotificationUseCase) FindAllNotification(ctx context.Context) ([]*responses.PostNotificationResponse, error) {
-	user, err := util.GetAuthUser(ctx)
+const (
+	defaultNotificationPageSize = 20
+)
 
+func (n NotificationUseCase) FindAllNotification(ctx context.Context, request *requests.NotificationPageRequest) (*responses.NotificationPageResponse, error) {
+	user, err := util.GetAuthUser(ctx)
 	if err != nil {
 		return nil, err
 	}
-	
-	notifications, err := n.notifRepo.FindAll(ctx, string(user.UserId))
+
+	cursor, err := util.DecodeCursor(request.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", notifications.ErrInvalidNotification, err.Error())
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultNotificationPageSize
+	}
+
+	notifs, hasMore, err := n.notifRepo.FindPage(ctx, user.UserId, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	var responseList []*responses.PostNotificationResponse
-	for _, notification := range notifications {
-		response := &responses.PostNotificationResponse{
-			ID:           notification.ID.String(),
-			SourceUserID: notification.SourceUserID.String(),
-			RecipientID:  notification.RecipientID.String(),
-			PostID:       notification.PostID.String(),
-			Content:      notification.Content,
-		}
-		responseList = append(responseList, response)
+	page := &responses.NotificationPageResponse{
+		Items: []*responses.PostNotificationResponse{},
 	}
-	return responseList, nil
+	for _, notification := range notifs {
+		page.Items = append(page.Items, toNotificationResponse(notification))
+	}
+	if hasMore && len(notifs) > 0 {
+		last := notifs[len(notifs)-1]
+		page.NextCursor = util.EncodeCursor(last.CreatedAt, last.ID)
+	}
+	return page, nil
+}
+
+func (n NotificationUseCase) DeleteNotification(ctx context.Context, notificationId string) error {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uuid.Parse(notificationId); err != nil {
+		return fmt.Errorf("%w: notification id must be a UUID", notifications.ErrInvalidNotification)
+	}
+
+	return n.notifRepo.DeleteNotification(ctx, user.UserId, notificationId)
+}
+
+func (n NotificationUseCase) MarkRead(ctx context.Context, notificationId string) error {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uuid.Parse(notificationId); err != nil {
+		return fmt.Errorf("%w: notification id must be a UUID", notifications.ErrInvalidNotification)
+	}
+
+	return n.notifRepo.MarkRead(ctx, user.UserId, notificationId)
+}
+
+func (n NotificationUseCase) MarkAllRead(ctx context.Context) (int64, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return n.notifRepo.MarkAllRead(ctx, user.UserId)
+}
+
+func (n NotificationUseCase) DeleteAllNotification(ctx context.Context) (int64, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return n.notifRepo.DeleteAllNotification(ctx, user.UserId)
 }
 
 func (n NotificationUseCase) NotifyNewPost(ctx context.Context, request *requests.PostNotificationRequest) (*responses.PostNotificationResponse, error) {
+	sourceUserID, err := uuid.Parse(request.SourceUserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: source_user_id must be a UUID", notifications.ErrInvalidNotification)
+	}
+	recipientID, err := uuid.Parse(request.RecipientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: recipient_id must be a UUID", notifications.ErrInvalidNotification)
+	}
+	postID, err := uuid.Parse(request.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: post_id must be a UUID", notifications.ErrInvalidNotification)
+	}
+
 	notifObject := &entities.Notification{
-		SourceUserID: uuid.MustParse(request.SourceUserID),
-		RecipientID:  uuid.MustParse(request.RecipientID),
-		PostID:       uuid.MustParse(request.PostID),
+		SourceUserID: sourceUserID,
+		RecipientID:  recipientID,
+		PostID:       postID,
 		Type:         util.NOTIF_POST,
 		Content:      request.Content,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	savedPost, err := n.notifRepo.SaveNotification(ctx, notifObject)
+	saved, err := n.dispatcher.Dispatch(ctx, []*entities.Notification{notifObject})
+	if err != nil {
+		return nil, err
+	}
+	if len(saved) == 0 {
+		return nil, notifications.ErrNotificationSuppressed
+	}
+	savedPost := saved[0]
+
+	return toNotificationResponse(savedPost), nil
+}
+
+func (n NotificationUseCase) FindPreference(ctx context.Context) (*responses.NotificationPreferenceResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := n.prefRepo.FindPreference(ctx, user.UserId)
 	if err != nil {
 		return nil, err
 	}
 
-	return &responses.PostNotificationResponse{
-		ID:           savedPost.ID.String(),
-		SourceUserID: savedPost.SourceUserID.String(),
-		RecipientID:  savedPost.RecipientID.String(),
-		PostID:       savedPost.PostID.String(),
-		Content:      savedPost.Content,
-	}, nil
+	return toPreferenceResponse(pref), nil
+}
+
+func (n NotificationUseCase) UpdatePreference(ctx context.Context, request *requests.UpdateNotificationPreferenceRequest) (*responses.NotificationPreferenceResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := n.prefRepo.FindPreference(ctx, user.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.NewPost != nil {
+		pref.NewPost = *request.NewPost
+	}
+	if request.Like != nil {
+		pref.Like = *request.Like
+	}
+	if request.Comment != nil {
+		pref.Comment = *request.Comment
+	}
+	if request.Reply != nil {
+		pref.Reply = *request.Reply
+	}
+	if request.Mention != nil {
+		pref.Mention = *request.Mention
+	}
+	if request.DigestFrequency != nil {
+		pref.DigestFrequency = *request.DigestFrequency
+	}
+
+	saved, err := n.prefRepo.SavePreference(ctx, pref)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPreferenceResponse(saved), nil
+}
+
+func (n NotificationUseCase) FindAllMute(ctx context.Context) ([]*responses.NotificationMuteResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mutes, err := n.prefRepo.FindMutes(ctx, user.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	responseList := []*responses.NotificationMuteResponse{}
+	for _, mute := range mutes {
+		responseList = append(responseList, toMuteResponse(mute))
+	}
+	return responseList, nil
+}
+
+func (n NotificationUseCase) CreateMute(ctx context.Context, request *requests.CreateNotificationMuteRequest) (*responses.NotificationMuteResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if (request.SourceUserID == "") == (request.PostID == "") {
+		return nil, errors.New("exactly one of source_user_id or post_id is required")
+	}
+
+	mute := &entities.NotificationMute{
+		UserID: uuid.MustParse(user.UserId),
+	}
+
+	if request.SourceUserID != "" {
+		sourceUserID, err := uuid.Parse(request.SourceUserID)
+		if err != nil {
+			return nil, errors.New("invalid source_user_id")
+		}
+		mute.SourceUserID = &sourceUserID
+	} else {
+		postID, err := uuid.Parse(request.PostID)
+		if err != nil {
+			return nil, errors.New("invalid post_id")
+		}
+		mute.PostID = &postID
+	}
+
+	saved, err := n.prefRepo.SaveMute(ctx, mute)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMuteResponse(saved), nil
+}
+
+func (n NotificationUseCase) DeleteMute(ctx context.Context, muteId string) error {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uuid.Parse(muteId); err != nil {
+		return errors.New("invalid mute id")
+	}
+
+	return n.prefRepo.DeleteMute(ctx, user.UserId, muteId)
+}
+
+func toNotificationResponse(notification *entities.Notification) *responses.PostNotificationResponse {
+	response := &responses.PostNotificationResponse{
+		ID:           notification.ID.String(),
+		SourceUserID: notification.SourceUserID.String(),
+		RecipientID:  notification.RecipientID.String(),
+		PostID:       notification.PostID.String(),
+		Type:         notification.Type,
+		Content:      notification.Content,
+		CreatedAt:    notification.CreatedAt.Format(time.RFC3339),
+	}
+	if notification.ReadAt != nil {
+		response.ReadAt = notification.ReadAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+func toPreferenceResponse(pref *entities.NotificationPreference) *responses.NotificationPreferenceResponse {
+	return &responses.NotificationPreferenceResponse{
+		NewPost: pref.NewPost,
+		Like:    pref.Like,
+		Comment: pref.Comment,
+		Reply:   pref.Reply,
+		Mention: pref.Mention,
+
+		DigestFrequency: pref.DigestFrequency,
+	}
+}
+
+func toMuteResponse(mute *entities.NotificationMute) *responses.NotificationMuteResponse {
+	response := &responses.NotificationMuteResponse{
+		ID:        mute.ID.String(),
+		CreatedAt: mute.CreatedAt.Format(time.RFC3339),
+	}
+	if mute.SourceUserID != nil {
+		response.SourceUserID = mute.SourceUserID.String()
+	}
+	if mute.PostID != nil {
+		response.PostID = mute.PostID.String()
+	}
+	return response
 }
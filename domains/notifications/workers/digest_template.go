@@ -0,0 +1,78 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	"bootcamp-content-interaction-service/shared/util"
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates/digest.html.tmpl templates/digest.txt.tmpl
+var digestTemplates embed.FS
+
+var (
+	digestHTML = htmltemplate.Must(htmltemplate.ParseFS(digestTemplates, "templates/digest.html.tmpl"))
+	digestText = texttemplate.Must(texttemplate.ParseFS(digestTemplates, "templates/digest.txt.tmpl"))
+)
+
+type digestView struct {
+	Subject     string
+	Name        string
+	Frequency   string
+	PeriodLabel string
+	Total       int64
+	More        int64
+	Items       []digestItem
+}
+
+type digestItem struct {
+	Summary string
+	Content string
+	At      string
+}
+
+func renderDigest(view *digestView) (string, string, error) {
+	var html, text bytes.Buffer
+	if err := digestHTML.Execute(&html, view); err != nil {
+		return "", "", err
+	}
+	if err := digestText.Execute(&text, view); err != nil {
+		return "", "", err
+	}
+	return html.String(), text.String(), nil
+}
+
+func newDigestItem(notification *entities.Notification) digestItem {
+	actor := notification.SourceUser.Name
+	if actor == "" {
+		actor = notification.SourceUser.Username
+	}
+	if actor == "" {
+		actor = "Someone"
+	}
+
+	var summary string
+	switch notification.Type {
+	case util.NOTIF_POST:
+		summary = actor + " shared a new post"
+	case util.NOTIF_LIKE:
+		summary = actor + " liked your post"
+	case util.NOTIF_COMMENT:
+		summary = actor + " commented on your post"
+	case util.NOTIF_REPLY:
+		summary = actor + " replied to your comment"
+	case util.NOTIF_MENTION:
+		summary = actor + " mentioned you"
+	default:
+		summary = actor + " interacted with you"
+	}
+
+	return digestItem{
+		Summary: summary,
+		Content: strings.TrimSpace(notification.Content),
+		At:      notification.CreatedAt.UTC().Format("Jan 2, 15:04 UTC"),
+	}
+}
@@ -0,0 +1,187 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/notifications"
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DigestWorker emails each recipient the notifications they left unread
+// during the last finished day or ISO week, depending on their digest
+// preference. It runs on a short interval and relies on the per-period
+// digest claim, so restarts and several replicas never mail a period twice.
+type DigestWorker struct {
+	repo   notifications.NotificationDigestRepository
+	mailer infrastructures.Mailer
+	conf   config.Digest
+	logger util.Logger
+}
+
+func NewDigestWorker(repo notifications.NotificationDigestRepository, mailer infrastructures.Mailer, conf config.Digest, logger util.Logger) *DigestWorker {
+	return &DigestWorker{
+		repo:   repo,
+		mailer: mailer,
+		conf:   conf.WithDefaults(),
+		logger: logger,
+	}
+}
+
+func (w *DigestWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *DigestWorker) RunOnce(ctx context.Context, now time.Time) {
+	for _, frequency := range []string{util.DIGEST_DAILY, util.DIGEST_WEEKLY} {
+		from, to, period := digestPeriod(frequency, now)
+		if err := w.sendPeriod(ctx, frequency, from, to, period); err != nil {
+			w.logger.Error("Notification digest run failed",
+				zap.String("period", period),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// sendPeriod walks the recipients of period once, in id order. A recipient
+// whose send fails is released and skipped, so it is retried on the next run
+// without holding up the recipients after it.
+func (w *DigestWorker) sendPeriod(ctx context.Context, frequency string, from time.Time, to time.Time, period string) error {
+	after := uuid.Nil
+	for {
+		recipients, err := w.repo.FindDigestRecipients(ctx, frequency, from, to, period, after, w.conf.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, recipient := range recipients {
+			if err := w.sendDigest(ctx, recipient, frequency, from, to, period); err != nil {
+				w.logger.Warn("Failed to send notification digest",
+					zap.String("user_id", recipient.ID.String()),
+					zap.String("period", period),
+					zap.Error(err),
+				)
+			}
+			after = recipient.ID
+		}
+
+		if len(recipients) < w.conf.BatchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (w *DigestWorker) sendDigest(ctx context.Context, recipient *users.User, frequency string, from time.Time, to time.Time, period string) error {
+	claimed, err := w.repo.ClaimDigest(ctx, recipient.ID, period)
+	if err != nil || !claimed {
+		return err
+	}
+
+	unread, total, err := w.repo.FindUnread(ctx, recipient.ID, from, to, w.conf.MaxItems)
+	if err == nil && total == 0 {
+		// Everything was read between the recipient query and the claim.
+		return w.repo.CompleteDigest(ctx, recipient.ID, period, 0)
+	}
+
+	var msg *infrastructures.MailMessage
+	if err == nil {
+		msg, err = buildDigestMessage(recipient, frequency, from, to, unread, total)
+	}
+	if err == nil {
+		err = w.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		if releaseErr := w.repo.ReleaseDigest(ctx, recipient.ID, period); releaseErr != nil {
+			w.logger.Error("Failed to release notification digest claim",
+				zap.String("user_id", recipient.ID.String()),
+				zap.String("period", period),
+				zap.Error(releaseErr),
+			)
+		}
+		return err
+	}
+
+	return w.repo.CompleteDigest(ctx, recipient.ID, period, total)
+}
+
+func buildDigestMessage(recipient *users.User, frequency string, from time.Time, to time.Time, unread []*entities.Notification, total int64) (*infrastructures.MailMessage, error) {
+	name := recipient.Name
+	if name == "" {
+		name = recipient.Username
+	}
+
+	periodLabel := from.Format("Monday, January 2")
+	if frequency == util.DIGEST_WEEKLY {
+		periodLabel = "the week of " + from.Format("January 2") + " to " + to.Add(-time.Second).Format("January 2")
+	}
+
+	view := &digestView{
+		Subject:     fmt.Sprintf("You have %d unread notification%s", total, plural(total)),
+		Name:        name,
+		Frequency:   strings.ToLower(frequency),
+		PeriodLabel: periodLabel,
+		Total:       total,
+		More:        total - int64(len(unread)),
+	}
+	for _, notification := range unread {
+		view.Items = append(view.Items, newDigestItem(notification))
+	}
+
+	html, text, err := renderDigest(view)
+	if err != nil {
+		return nil, err
+	}
+
+	return &infrastructures.MailMessage{
+		To:      (&mail.Address{Name: name, Address: recipient.Email}).String(),
+		Subject: view.Subject,
+		HTML:    html,
+		Text:    text,
+	}, nil
+}
+
+// digestPeriod returns the last finished period for frequency, in UTC, and
+// the key that identifies it: the previous day for daily digests and the
+// previous ISO week for weekly ones.
+func digestPeriod(frequency string, now time.Time) (time.Time, time.Time, string) {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	if frequency == util.DIGEST_WEEKLY {
+		daysSinceMonday := (int(today.Weekday()) + 6) % 7
+		to := today.AddDate(0, 0, -daysSinceMonday)
+		from := to.AddDate(0, 0, -7)
+		year, week := from.ISOWeek()
+		return from, to, fmt.Sprintf("%s:%d-W%02d", util.DIGEST_WEEKLY, year, week)
+	}
+
+	from := today.AddDate(0, 0, -1)
+	return from, today, util.DIGEST_DAILY + ":" + from.Format("2006-01-02")
+}
+
+func plural(count int64) string {
+	if count == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,154 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/domains/notifications"
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	"bootcamp-content-interaction-service/domains/notifications/models/jobs"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	JOB_NEW_POST_FANOUT      = "notification.new_post_fanout"
+	JOB_DELIVER_NOTIFICATION = "notification.deliver"
+
+	recipientsPerJob = 500
+)
+
+// FanoutWorker turns a new post into notifications for every follower of its
+// author. Followers are read one page at a time and each page becomes its own
+// delivery job, so the full follower list is never held in memory. A fan-out
+// that fails partway is retried from the first page and enqueues the earlier
+// pages again; their inserts are no-ops because a follower holds at most one
+// NEW_POST notification per post.
+type FanoutWorker struct {
+	queue      infrastructures.JobQueue
+	followers  notifications.FollowerSource
+	dispatcher notifications.NotificationDispatcher
+	logger     util.Logger
+}
+
+func NewFanoutWorker(queue infrastructures.JobQueue, followers notifications.FollowerSource, dispatcher notifications.NotificationDispatcher, logger util.Logger) *FanoutWorker {
+	return &FanoutWorker{
+		queue:      queue,
+		followers:  followers,
+		dispatcher: dispatcher,
+		logger:     logger,
+	}
+}
+
+func (w *FanoutWorker) EnqueueNewPost(ctx context.Context, sourceUserId uuid.UUID, postId uuid.UUID, content string) error {
+	err := w.queue.Enqueue(ctx, JOB_NEW_POST_FANOUT, jobs.NewPostFanoutJob{
+		SourceUserID: sourceUserId.String(),
+		PostID:       postId.String(),
+		Content:      content,
+	})
+	if err != nil {
+		w.logger.Error("Failed to enqueue notification fan-out",
+			zap.String("post_id", postId.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (w *FanoutWorker) Start(ctx context.Context) {
+	w.queue.Consume(ctx, w.handle)
+}
+
+func (w *FanoutWorker) handle(ctx context.Context, job *infrastructures.Job) error {
+	switch job.Type {
+	case JOB_NEW_POST_FANOUT:
+		var payload jobs.NewPostFanoutJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return err
+		}
+		return w.fanout(ctx, &payload)
+	case JOB_DELIVER_NOTIFICATION:
+		var payload jobs.DeliverNotificationJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return err
+		}
+		return w.deliver(ctx, &payload)
+	default:
+		return fmt.Errorf("unknown job type: %s", job.Type)
+	}
+}
+
+func (w *FanoutWorker) fanout(ctx context.Context, payload *jobs.NewPostFanoutJob) error {
+	cursor := ""
+	total := 0
+	for {
+		followers, next, err := w.followers.GetFollowersPage(ctx, payload.SourceUserID, cursor, recipientsPerJob)
+		if err != nil {
+			return err
+		}
+
+		if len(followers) > 0 {
+			err = w.queue.Enqueue(ctx, JOB_DELIVER_NOTIFICATION, jobs.DeliverNotificationJob{
+				SourceUserID: payload.SourceUserID,
+				PostID:       payload.PostID,
+				Type:         util.NOTIF_POST,
+				Content:      payload.Content,
+				RecipientIDs: followers,
+			})
+			if err != nil {
+				return err
+			}
+			total += len(followers)
+		}
+
+		if next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+
+	w.logger.Info("Fanned out new post notification",
+		zap.String("post_id", payload.PostID),
+		zap.Int("follower_count", total),
+	)
+
+	return nil
+}
+
+func (w *FanoutWorker) deliver(ctx context.Context, payload *jobs.DeliverNotificationJob) error {
+	sourceUserID, err := uuid.Parse(payload.SourceUserID)
+	if err != nil {
+		return err
+	}
+	postID, err := uuid.Parse(payload.PostID)
+	if err != nil {
+		return err
+	}
+
+	var notifs []*entities.Notification
+	for _, recipient := range payload.RecipientIDs {
+		recipientID, err := uuid.Parse(recipient)
+		if err != nil {
+			w.logger.Warn("Skipping invalid recipient id",
+				zap.String("recipient_id", recipient),
+			)
+			continue
+		}
+
+		notifs = append(notifs, &entities.Notification{
+			SourceUserID: sourceUserID,
+			RecipientID:  recipientID,
+			PostID:       postID,
+			Type:         payload.Type,
+			Content:      payload.Content,
+		})
+	}
+
+	_, err = w.dispatcher.Dispatch(ctx, notifs)
+	return err
+}
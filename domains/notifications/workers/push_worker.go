@@ -0,0 +1,180 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/domains/devices"
+	"bootcamp-content-interaction-service/domains/notifications/entities"
+	"bootcamp-content-interaction-service/domains/notifications/models/jobs"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	JOB_SEND_PUSH = "notification.send_push"
+
+	pushBodyLength = 140
+)
+
+// PushWorker delivers saved notifications to the recipients' registered
+// devices. Pushes are best effort: a device that fails is logged and skipped
+// rather than retried, so a retry never shows the same push twice, and tokens
+// the provider reports as invalid are deleted.
+type PushWorker struct {
+	queue    infrastructures.JobQueue
+	devices  devices.DeviceRepository
+	provider infrastructures.PushProvider
+	logger   util.Logger
+}
+
+func NewPushWorker(queue infrastructures.JobQueue, deviceRepo devices.DeviceRepository, provider infrastructures.PushProvider, logger util.Logger) *PushWorker {
+	return &PushWorker{
+		queue:    queue,
+		devices:  deviceRepo,
+		provider: provider,
+		logger:   logger,
+	}
+}
+
+func (w *PushWorker) EnqueuePush(ctx context.Context, notifs []*entities.Notification) error {
+	if len(notifs) == 0 {
+		return nil
+	}
+
+	job := jobs.SendPushJob{}
+	for _, notif := range notifs {
+		job.Notifications = append(job.Notifications, jobs.PushNotificationItem{
+			NotificationID: notif.ID.String(),
+			RecipientID:    notif.RecipientID.String(),
+			PostID:         notif.PostID.String(),
+			Type:           notif.Type,
+			Content:        notif.Content,
+		})
+	}
+
+	return w.queue.Enqueue(ctx, JOB_SEND_PUSH, job)
+}
+
+func (w *PushWorker) Start(ctx context.Context) {
+	w.queue.Consume(ctx, w.handle)
+}
+
+func (w *PushWorker) handle(ctx context.Context, job *infrastructures.Job) error {
+	if job.Type != JOB_SEND_PUSH {
+		return fmt.Errorf("unknown job type: %s", job.Type)
+	}
+
+	var payload jobs.SendPushJob
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return err
+	}
+
+	return w.send(ctx, &payload)
+}
+
+func (w *PushWorker) send(ctx context.Context, payload *jobs.SendPushJob) error {
+	var recipientIDs []uuid.UUID
+	for _, item := range payload.Notifications {
+		recipientID, err := uuid.Parse(item.RecipientID)
+		if err != nil {
+			continue
+		}
+		recipientIDs = append(recipientIDs, recipientID)
+	}
+
+	tokens, err := w.devices.FindDevicesByUsers(ctx, recipientIDs)
+	if err != nil {
+		return err
+	}
+
+	byRecipient := make(map[string][]*infrastructures.PushTarget)
+	for _, token := range tokens {
+		byRecipient[token.UserID.String()] = append(byRecipient[token.UserID.String()], &infrastructures.PushTarget{
+			Platform: token.Platform,
+			Token:    token.Token,
+		})
+	}
+
+	var invalid []string
+	sent := 0
+	for _, item := range payload.Notifications {
+		targets := byRecipient[item.RecipientID]
+		if len(targets) == 0 {
+			continue
+		}
+
+		msg := newPushMessage(&item)
+		for _, target := range targets {
+			err := w.provider.Send(ctx, target, msg)
+			if errors.Is(err, infrastructures.ErrInvalidPushToken) {
+				invalid = append(invalid, target.Token)
+				continue
+			}
+			if err != nil {
+				w.logger.Warn("Failed to send push notification",
+					zap.String("notification_id", item.NotificationID),
+					zap.String("platform", target.Platform),
+					zap.Error(err),
+				)
+				continue
+			}
+			sent++
+		}
+	}
+
+	if len(invalid) > 0 {
+		if _, err := w.devices.DeleteTokens(ctx, invalid); err != nil {
+			w.logger.Error("Failed to prune invalid device tokens", zap.Error(err))
+		}
+	}
+
+	w.logger.Info("Push notifications sent",
+		zap.Int("notifications", len(payload.Notifications)),
+		zap.Int("sent", sent),
+		zap.Int("pruned", len(invalid)),
+	)
+
+	return nil
+}
+
+func newPushMessage(item *jobs.PushNotificationItem) *infrastructures.PushMessage {
+	var title, body string
+	switch item.Type {
+	case util.NOTIF_POST:
+		title, body = "New post", "Someone you follow shared a new post"
+	case util.NOTIF_LIKE:
+		title, body = "New like", "Someone liked your post"
+	case util.NOTIF_COMMENT:
+		title, body = "New comment", "Someone commented on your post"
+	case util.NOTIF_REPLY:
+		title, body = "New reply", "Someone replied to your comment"
+	case util.NOTIF_MENTION:
+		title, body = "New mention", "Someone mentioned you"
+	default:
+		title, body = "New notification", "You have a new notification"
+	}
+
+	if item.Content != "" {
+		content := []rune(item.Content)
+		if len(content) > pushBodyLength {
+			content = append(content[:pushBodyLength-1], '…')
+		}
+		body = string(content)
+	}
+
+	return &infrastructures.PushMessage{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"notification_id": item.NotificationID,
+			"type":            item.Type,
+			"post_id":         item.PostID,
+		},
+		CollapseKey: item.PostID,
+	}
+}
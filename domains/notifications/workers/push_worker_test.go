@@ -0,0 +1,122 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/domains/devices/entities"
+	"bootcamp-content-interaction-service/domains/notifications/models/jobs"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type fakeDeviceRepository struct {
+	tokens  []*entities.DeviceToken
+	deleted []string
+}
+
+func (r *fakeDeviceRepository) SaveDevice(ctx context.Context, device *entities.DeviceToken) (*entities.DeviceToken, error) {
+	r.tokens = append(r.tokens, device)
+	return device, nil
+}
+
+func (r *fakeDeviceRepository) FindDevices(ctx context.Context, userId string) ([]*entities.DeviceToken, error) {
+	var found []*entities.DeviceToken
+	for _, token := range r.tokens {
+		if token.UserID.String() == userId {
+			found = append(found, token)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeDeviceRepository) FindDevicesByUsers(ctx context.Context, userIds []uuid.UUID) ([]*entities.DeviceToken, error) {
+	var found []*entities.DeviceToken
+	for _, token := range r.tokens {
+		for _, userId := range userIds {
+			if token.UserID == userId {
+				found = append(found, token)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeDeviceRepository) DeleteDevice(ctx context.Context, userId string, id string) error {
+	return nil
+}
+
+func (r *fakeDeviceRepository) DeleteTokens(ctx context.Context, tokens []string) (int64, error) {
+	r.deleted = append(r.deleted, tokens...)
+	return int64(len(tokens)), nil
+}
+
+func TestPushWorkerPrunesInvalidTokens(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	repo := &fakeDeviceRepository{tokens: []*entities.DeviceToken{
+		{UserID: alice, Platform: util.PLATFORM_ANDROID, Token: "alice-android"},
+		{UserID: alice, Platform: util.PLATFORM_IOS, Token: "alice-ios-stale"},
+		{UserID: bob, Platform: util.PLATFORM_WEB, Token: "bob-web-stale"},
+		{UserID: bob, Platform: util.PLATFORM_IOS, Token: "bob-ios"},
+	}}
+	provider := infrastructures.NewFakePushProvider(nil)
+	provider.MarkInvalid("alice-ios-stale")
+	provider.MarkInvalid("bob-web-stale")
+
+	w := NewPushWorker(nil, repo, provider, util.NewNopLogger())
+
+	payload, err := json.Marshal(jobs.SendPushJob{Notifications: []jobs.PushNotificationItem{
+		{NotificationID: uuid.NewString(), RecipientID: alice.String(), PostID: uuid.NewString(), Type: util.NOTIF_LIKE},
+		{NotificationID: uuid.NewString(), RecipientID: bob.String(), PostID: uuid.NewString(), Type: util.NOTIF_COMMENT, Content: "nice"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.handle(context.Background(), &infrastructures.Job{Type: JOB_SEND_PUSH, Payload: payload})
+	if err != nil {
+		t.Fatalf("handle returned %v", err)
+	}
+
+	deleted := map[string]bool{}
+	for _, token := range repo.deleted {
+		deleted[token] = true
+	}
+	if len(repo.deleted) != 2 || !deleted["alice-ios-stale"] || !deleted["bob-web-stale"] {
+		t.Fatalf("deleted tokens = %v, want the two stale tokens", repo.deleted)
+	}
+
+	sent := provider.Sent()
+	if len(sent) != 2 {
+		t.Fatalf("sent %d pushes, want 2", len(sent))
+	}
+	for _, push := range sent {
+		if push.Token != "alice-android" && push.Token != "bob-ios" {
+			t.Errorf("push sent to unexpected token %q", push.Token)
+		}
+	}
+}
+
+func TestPushWorkerSkipsPruneWhenAllTokensValid(t *testing.T) {
+	alice := uuid.New()
+	repo := &fakeDeviceRepository{tokens: []*entities.DeviceToken{
+		{UserID: alice, Platform: util.PLATFORM_ANDROID, Token: "alice-android"},
+	}}
+	provider := infrastructures.NewFakePushProvider(nil)
+
+	w := NewPushWorker(nil, repo, provider, util.NewNopLogger())
+	err := w.send(context.Background(), &jobs.SendPushJob{Notifications: []jobs.PushNotificationItem{
+		{NotificationID: uuid.NewString(), RecipientID: alice.String(), PostID: uuid.NewString(), Type: util.NOTIF_POST},
+	}})
+	if err != nil {
+		t.Fatalf("send returned %v", err)
+	}
+	if len(repo.deleted) != 0 {
+		t.Fatalf("deleted tokens = %v, want none", repo.deleted)
+	}
+	if got := len(provider.Sent()); got != 1 {
+		t.Fatalf("sent %d pushes, want 1", got)
+	}
+}
@@ -0,0 +1,67 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/notifications"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetentionWorker deletes notifications older than the configured max age.
+// Rows are removed in batches so a large backlog never holds one long
+// delete, and each batch drops the cached inbox of the recipients it touched.
+type RetentionWorker struct {
+	repo   notifications.NotificationRepository
+	conf   config.Retention
+	logger util.Logger
+}
+
+func NewRetentionWorker(repo notifications.NotificationRepository, conf config.Retention, logger util.Logger) *RetentionWorker {
+	return &RetentionWorker{
+		repo:   repo,
+		conf:   conf.WithDefaults(),
+		logger: logger,
+	}
+}
+
+func (w *RetentionWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *RetentionWorker) sweep(ctx context.Context) {
+	cutoff := time.Now().Add(-w.conf.MaxAge)
+
+	var total int64
+	for {
+		deleted, err := w.repo.DeleteOlderThan(ctx, cutoff, w.conf.BatchSize)
+		if err != nil {
+			w.logger.Error("Notification retention sweep failed", zap.Error(err))
+			return
+		}
+		total += deleted
+		if deleted < int64(w.conf.BatchSize) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Info("Deleted expired notifications",
+			zap.Int64("deleted", total),
+			zap.Time("cutoff", cutoff),
+		)
+	}
+}
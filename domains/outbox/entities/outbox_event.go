@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type OutboxEvent struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	EventType     string         `gorm:"type:varchar(100);not null"`
+	AggregateID   uuid.UUID      `gorm:"type:uuid;not null"`
+	Payload       string         `gorm:"type:jsonb;not null"`
+	Attempts      int            `gorm:"not null;default:0"`
+	LastError     string         `gorm:"type:text"`
+	DeliveredTo   pq.StringArray `gorm:"type:text[]"`
+	NextAttemptAt time.Time      `gorm:"type:timestamp;index:idx_outbox_pending,where:published_at IS NULL AND failed_at IS NULL"`
+	PublishedAt   *time.Time     `gorm:"type:timestamp"`
+	FailedAt      *time.Time     `gorm:"type:timestamp"`
+	CreatedAt     time.Time      `gorm:"type:timestamp"`
+}
@@ -0,0 +1,17 @@
+package outbox
+
+import (
+	"bootcamp-content-interaction-service/domains/outbox/entities"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	Append(tx *gorm.DB, eventType string, aggregateId uuid.UUID, payload interface{}) error
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEvent, error)
+	SaveProgress(ctx context.Context, event *entities.OutboxEvent) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
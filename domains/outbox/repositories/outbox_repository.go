@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/outbox"
+	"bootcamp-content-interaction-service/domains/outbox/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OutboxRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+}
+
+func NewOutboxRepository(db infrastructures.Database, logger util.Logger) outbox.OutboxRepository {
+	return OutboxRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Append records an event on the caller's transaction, so it is committed or
+// rolled back together with the entity change it describes.
+func (o OutboxRepository) Append(tx *gorm.DB, eventType string, aggregateId uuid.UUID, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	event := &entities.OutboxEvent{
+		ID:            uuid.New(),
+		EventType:     eventType,
+		AggregateID:   aggregateId,
+		Payload:       string(payloadJSON),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	return tx.Create(event).Error
+}
+
+// ClaimPending leases up to limit due events by pushing their next attempt
+// past the lease. The row locks (SKIP LOCKED, so several relays can run side
+// by side) last only for this statement; subscribers run afterwards, outside
+// any transaction, and an event left behind by a crashed relay comes back
+// once its lease runs out.
+func (o OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEvent, error) {
+	var claimed []*entities.OutboxEvent
+
+	now := time.Now()
+	result := o.db.GetInstance().WithContext(ctx).Raw(`
+		UPDATE outbox_events SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE published_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?
+			ORDER BY created_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), now, limit).
+		Scan(&claimed)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	sort.Slice(claimed, func(i, j int) bool {
+		return claimed[i].CreatedAt.Before(claimed[j].CreatedAt)
+	})
+	return claimed, nil
+}
+
+// SaveProgress stores what the relay recorded on a claimed event.
+func (o OutboxRepository) SaveProgress(ctx context.Context, event *entities.OutboxEvent) error {
+	return o.db.GetInstance().WithContext(ctx).
+		Model(&entities.OutboxEvent{}).
+		Where("id = ?", event.ID).
+		Updates(map[string]interface{}{
+			"attempts":        event.Attempts,
+			"last_error":      event.LastError,
+			"delivered_to":    event.DeliveredTo,
+			"next_attempt_at": event.NextAttemptAt,
+			"published_at":    event.PublishedAt,
+			"failed_at":       event.FailedAt,
+		}).Error
+}
+
+func (o OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := o.db.GetInstance().WithContext(ctx).
+		Where("published_at IS NOT NULL AND published_at < ?", before).
+		Delete(&entities.OutboxEvent{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,141 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/domains/outbox"
+	"bootcamp-content-interaction-service/domains/outbox/entities"
+	"bootcamp-content-interaction-service/shared/events"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	relayInterval    = 500 * time.Millisecond
+	relayBatchSize   = 100
+	relayLease       = 5 * time.Minute
+	relayMaxAttempts = 12
+	relayMaxBackoff  = 5 * time.Minute
+	relayRetention   = 7 * 24 * time.Hour
+)
+
+// Relay drains the outbox table into the event bus. An event stays pending
+// until every subscriber has handled it, so delivery is at-least-once and
+// subscribers are expected to be idempotent. An event that still fails after
+// relayMaxAttempts is marked failed and left in the table for inspection.
+type Relay struct {
+	repo   outbox.OutboxRepository
+	bus    events.EventBus
+	logger util.Logger
+}
+
+func NewRelay(repo outbox.OutboxRepository, bus events.EventBus, logger util.Logger) *Relay {
+	return &Relay{
+		repo:   repo,
+		bus:    bus,
+		logger: logger,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(time.Hour)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanup.C:
+			deleted, err := r.repo.DeletePublishedBefore(ctx, time.Now().Add(-relayRetention))
+			if err != nil {
+				r.logger.Warn("Failed to clean up outbox", zap.Error(err))
+			} else if deleted > 0 {
+				r.logger.Info("Cleaned up published outbox events", zap.Int64("deleted", deleted))
+			}
+		case <-ticker.C:
+			for {
+				processed, err := r.RunOnce(ctx)
+				if err != nil {
+					r.logger.Error("Outbox relay failed", zap.Error(err))
+					break
+				}
+				if processed < relayBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RunOnce claims and publishes one batch, returning how many events it
+// claimed.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	claimed, err := r.repo.ClaimPending(ctx, relayBatchSize, relayLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range claimed {
+		if err := r.publish(ctx, event); err != nil {
+			r.logger.Warn("Outbox event not fully delivered",
+				zap.String("event_id", event.ID.String()),
+				zap.String("event_type", event.EventType),
+				zap.Int("attempts", event.Attempts),
+				zap.Error(err),
+			)
+		}
+
+		if err := r.repo.SaveProgress(ctx, event); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(claimed), nil
+}
+
+func (r *Relay) publish(ctx context.Context, event *entities.OutboxEvent) error {
+	delivered, err := r.bus.Publish(ctx, &events.Event{
+		ID:          event.ID.String(),
+		Type:        event.EventType,
+		AggregateID: event.AggregateID.String(),
+		Payload:     json.RawMessage(event.Payload),
+		OccurredAt:  event.CreatedAt,
+	}, event.DeliveredTo)
+
+	event.DeliveredTo = delivered
+	if err != nil {
+		event.Attempts++
+		event.LastError = err.Error()
+		if event.Attempts >= relayMaxAttempts {
+			now := time.Now()
+			event.FailedAt = &now
+			r.logger.Error("Outbox event exhausted its attempts, marking it failed",
+				zap.String("event_id", event.ID.String()),
+				zap.String("event_type", event.EventType),
+				zap.Strings("delivered_to", event.DeliveredTo),
+				zap.Error(err),
+			)
+			return err
+		}
+		event.NextAttemptAt = time.Now().Add(backoff(event.Attempts))
+		return err
+	}
+
+	now := time.Now()
+	event.PublishedAt = &now
+	event.LastError = ""
+	return nil
+}
+
+func backoff(attempts int) time.Duration {
+	delay := time.Second << attempts
+	if delay <= 0 || delay > relayMaxBackoff {
+		return relayMaxBackoff
+	}
+	return delay
+}
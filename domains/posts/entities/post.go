@@ -12,9 +12,23 @@ type Post struct {
     ID         uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
     UserID     uuid.UUID      `gorm:"type:uuid;not null"`
 	User       entities.User  `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-    ImageURLs  pq.StringArray `gorm:"type:text[]"`
+    ImageKeys  pq.StringArray `gorm:"type:text[]"`
+    // ImageRenditions is a JSON array holding, per entry of ImageKeys, a map
+    // from rendition name to blob key. ImageIDs and ImageAltTexts run
+    // parallel to ImageKeys as well.
+    ImageRenditions string    `gorm:"type:jsonb;not null;default:'[]'"`
+    ImageIDs      pq.StringArray `gorm:"type:text[]"`
+    ImageAltTexts pq.StringArray `gorm:"type:text[]"`
+    // MediaInfo is a JSON array parallel to ImageKeys holding the type and
+    // probed dimensions of every item. Items it does not cover are images.
+    MediaInfo  string         `gorm:"type:jsonb;not null;default:'[]'"`
     Caption    string         `gorm:"type:text"`
     Tags       pq.StringArray `gorm:"type:text[]"`
+    // SearchVector indexes Caption for full-text search. Postgres keeps it
+    // in step with Caption, so the service never reads or writes it.
+    SearchVector string       `json:"-" gorm:"type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(caption, ''))) STORED;index:idx_posts_search_vector,type:gin;->:false;<-:false"`
+    // Revision goes up by one with every change to the post.
+    Revision   int            `gorm:"not null;default:1"`
     CreatedAt  time.Time      `gorm:"type:timestamp"`
     UpdatedAt  time.Time      `gorm:"type:timestamp"`
 }
\ No newline at end of file
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostSave is a post bookmarked by a user.
+type PostSave struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_post_save_user_post"`
+	User      users.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	PostID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_post_save_user_post;index"`
+	Post      Post       `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time  `gorm:"type:timestamp"`
+}
+
+// PostEngagement is a post with its engagement totals, used to seed the
+// explore ranking.
+type PostEngagement struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+	Likes     int64
+	Comments  int64
+	Saves     int64
+}
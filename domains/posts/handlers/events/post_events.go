@@ -0,0 +1,104 @@
+package events
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/shared/events"
+	"context"
+	"time"
+)
+
+type PostEventHandler struct {
+	postRepo    posts.PostRepository
+	exploreRepo posts.ExploreRepository
+	conf        config.Explore
+}
+
+func NewPostEventHandler(postRepo posts.PostRepository, exploreRepo posts.ExploreRepository, conf config.Explore) *PostEventHandler {
+	return &PostEventHandler{
+		postRepo:    postRepo,
+		exploreRepo: exploreRepo,
+		conf:        conf.WithDefaults(),
+	}
+}
+
+func (h *PostEventHandler) Register(bus events.EventBus) {
+	bus.Subscribe(events.POST_CREATED, "posts.cache", h.InvalidateCache)
+	bus.Subscribe(events.POST_UPDATED, "posts.cache", h.InvalidateCache)
+	bus.Subscribe(events.POST_DELETED, "posts.cache", h.InvalidateCache)
+
+	bus.Subscribe(events.POST_CREATED, "posts.explore", h.AddToExplore)
+	bus.Subscribe(events.POST_DELETED, "posts.explore", h.RemoveFromExplore)
+	bus.Subscribe(events.LIKE_ADDED, "posts.explore", h.engage(h.conf.LikeWeight))
+	bus.Subscribe(events.LIKE_REMOVED, "posts.explore", h.disengage(h.conf.LikeWeight))
+	bus.Subscribe(events.COMMENT_CREATED, "posts.explore", h.engage(h.conf.CommentWeight))
+	bus.Subscribe(events.COMMENT_DELETED, "posts.explore", h.disengage(h.conf.CommentWeight))
+	bus.Subscribe(events.POST_SAVED, "posts.explore", h.engage(h.conf.SaveWeight))
+	bus.Subscribe(events.POST_UNSAVED, "posts.explore", h.disengage(h.conf.SaveWeight))
+}
+
+func (h *PostEventHandler) InvalidateCache(ctx context.Context, event *events.Event) error {
+	var payload events.PostPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	return h.postRepo.InvalidateCache(ctx, payload.PostID.String(), payload.UserID.String())
+}
+
+func (h *PostEventHandler) AddToExplore(ctx context.Context, event *events.Event) error {
+	var payload events.PostPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	return h.exploreRepo.AddPost(ctx, payload.PostID.String(), event.OccurredAt, h.conf.PostWeight)
+}
+
+func (h *PostEventHandler) RemoveFromExplore(ctx context.Context, event *events.Event) error {
+	var payload events.PostPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	return h.exploreRepo.RemovePost(ctx, payload.PostID.String())
+}
+
+// engagementPayload is the part that like, comment and save payloads share,
+// so one decoder serves them all.
+type engagementPayload struct {
+	PostID    string     `json:"post_id"`
+	EngagedAt *time.Time `json:"engaged_at"`
+}
+
+// engage scores an engagement event against the post it targets.
+func (h *PostEventHandler) engage(weight float64) events.Handler {
+	return func(ctx context.Context, event *events.Event) error {
+		var payload engagementPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+
+		return h.exploreRepo.Engage(ctx, payload.PostID, weight, event.OccurredAt)
+	}
+}
+
+// disengage takes back what engage added for the removed engagement. Scores
+// use forward decay, so the weight has to be scaled to the time the
+// engagement was made: scaled to the removal time it would take back more
+// than was ever added.
+func (h *PostEventHandler) disengage(weight float64) events.Handler {
+	return func(ctx context.Context, event *events.Event) error {
+		var payload engagementPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+
+		engagedAt := event.OccurredAt
+		if payload.EngagedAt != nil {
+			engagedAt = *payload.EngagedAt
+		}
+
+		return h.exploreRepo.Engage(ctx, payload.PostID, -weight, engagedAt)
+	}
+}
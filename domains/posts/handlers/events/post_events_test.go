@@ -0,0 +1,72 @@
+package events
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/shared/events"
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeExploreRepository applies the same forward decay as the Redis scripts,
+// with a fixed epoch.
+type fakeExploreRepository struct {
+	posts.ExploreRepository
+	epoch    time.Time
+	halfLife time.Duration
+	scores   map[string]float64
+}
+
+func (r *fakeExploreRepository) Engage(ctx context.Context, postId string, weight float64, at time.Time) error {
+	r.scores[postId] += weight * math.Pow(2, at.Sub(r.epoch).Seconds()/r.halfLife.Seconds())
+	return nil
+}
+
+func publish(t *testing.T, bus events.EventBus, eventType string, at time.Time, payload interface{}) {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = bus.Publish(context.Background(), &events.Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Payload:    raw,
+		OccurredAt: at,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnlikeTakesBackWhatTheLikeAdded(t *testing.T) {
+	conf := config.Explore{HalfLife: 6 * time.Hour}.WithDefaults()
+	epoch := time.Now().Add(-24 * time.Hour)
+	exploreRepo := &fakeExploreRepository{
+		epoch:    epoch,
+		halfLife: conf.HalfLife,
+		scores:   map[string]float64{},
+	}
+
+	bus := events.NewInMemoryEventBus()
+	NewPostEventHandler(nil, exploreRepo, conf).Register(bus)
+
+	postID := uuid.New()
+	likedAt := epoch.Add(2 * time.Hour)
+	unlikedAt := likedAt.Add(12 * time.Hour)
+
+	publish(t, bus, events.LIKE_ADDED, likedAt, events.LikePayload{PostID: postID, UserID: uuid.New()})
+	if exploreRepo.scores[postID.String()] <= 0 {
+		t.Fatalf("score = %v after the like, want it raised", exploreRepo.scores[postID.String()])
+	}
+
+	publish(t, bus, events.LIKE_REMOVED, unlikedAt, events.LikePayload{PostID: postID, UserID: uuid.New(), EngagedAt: &likedAt})
+	if score := exploreRepo.scores[postID.String()]; math.Abs(score) > 1e-9 {
+		t.Errorf("score = %v after the unlike, want 0", score)
+	}
+}
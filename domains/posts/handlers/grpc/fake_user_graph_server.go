@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	usergraphv1 "bootcamp-content-interaction-service/proto/usergraph/v1"
+	"context"
+	"net"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// FakeUserGraphServer serves the UserGraph API from memory over an
+// in-process listener. Cursors are plain offsets into the stored lists.
+type FakeUserGraphServer struct {
+	usergraphv1.UnimplementedUserGraphServer
+
+	mu         sync.Mutex
+	followers  map[string][]string
+	followings map[string][]string
+	failures   []codes.Code
+	calls      int
+
+	listener *bufconn.Listener
+	server   *grpc.Server
+}
+
+func NewFakeUserGraphServer() *FakeUserGraphServer {
+	fake := &FakeUserGraphServer{
+		followers:  make(map[string][]string),
+		followings: make(map[string][]string),
+		listener:   bufconn.Listen(1 << 20),
+		server:     grpc.NewServer(),
+	}
+	usergraphv1.RegisterUserGraphServer(fake.server, fake)
+	go fake.server.Serve(fake.listener)
+
+	return fake
+}
+
+// Dial returns a client connection to the fake.
+func (f *FakeUserGraphServer) Dial() (*grpc.ClientConn, error) {
+	return grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return f.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
+
+func (f *FakeUserGraphServer) Stop() {
+	f.server.Stop()
+}
+
+func (f *FakeUserGraphServer) SetFollowers(userID string, followerIDs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.followers[userID] = followerIDs
+}
+
+func (f *FakeUserGraphServer) SetFollowings(userID string, followingIDs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.followings[userID] = followingIDs
+}
+
+// FailNext makes the next calls fail with the given codes, one per call.
+func (f *FakeUserGraphServer) FailNext(codes ...codes.Code) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, codes...)
+}
+
+// Calls reports how many requests reached the fake, failed ones included.
+func (f *FakeUserGraphServer) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *FakeUserGraphServer) ListFollowers(ctx context.Context, request *usergraphv1.ListRelationsRequest) (*usergraphv1.ListRelationsResponse, error) {
+	return f.list(f.followers, request)
+}
+
+func (f *FakeUserGraphServer) ListFollowings(ctx context.Context, request *usergraphv1.ListRelationsRequest) (*usergraphv1.ListRelationsResponse, error) {
+	return f.list(f.followings, request)
+}
+
+func (f *FakeUserGraphServer) list(relations map[string][]string, request *usergraphv1.ListRelationsRequest) (*usergraphv1.ListRelationsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if len(f.failures) > 0 {
+		code := f.failures[0]
+		f.failures = f.failures[1:]
+		return nil, status.Error(code, "fake failure")
+	}
+
+	offset := 0
+	if request.GetCursor() != "" {
+		var err error
+		offset, err = strconv.Atoi(request.GetCursor())
+		if err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+	}
+
+	ids := relations[request.GetUserId()]
+	if offset > len(ids) {
+		offset = len(ids)
+	}
+	end := len(ids)
+	if limit := int(request.GetLimit()); limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	response := &usergraphv1.ListRelationsResponse{
+		UserIds: append([]string(nil), ids[offset:end]...),
+	}
+	if end < len(ids) {
+		response.NextCursor = strconv.Itoa(end)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"bootcamp-content-interaction-service/config"
+	userGraphHttp "bootcamp-content-interaction-service/domains/posts/handlers/http"
+	usergraphv1 "bootcamp-content-interaction-service/proto/usergraph/v1"
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type userGraphGRPC struct {
+	client usergraphv1.UserGraphClient
+	caller *userGraphHttp.UserGraphCaller
+}
+
+// NewUserGraphGRPC implements UserGraphService over gRPC with the same
+// timeout, retry and circuit breaker policy as the HTTP client.
+func NewUserGraphGRPC(conn grpc.ClientConnInterface, conf config.UserGraph) userGraphHttp.UserGraphService {
+	return &userGraphGRPC{
+		client: usergraphv1.NewUserGraphClient(conn),
+		caller: userGraphHttp.NewUserGraphCaller(conf),
+	}
+}
+
+// DialUserGraph opens a plaintext connection to the user graph service. The
+// connection is established lazily on the first call.
+func DialUserGraph(address string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+func (g *userGraphGRPC) GetFollowings(ctx context.Context, userID string) ([]string, error) {
+	return userGraphHttp.CollectPages(ctx, userID, g.GetFollowingsPage)
+}
+
+func (g *userGraphGRPC) GetFollowers(ctx context.Context, userID string) ([]string, error) {
+	return userGraphHttp.CollectPages(ctx, userID, g.GetFollowersPage)
+}
+
+func (g *userGraphGRPC) GetFollowingsPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
+	return g.list(ctx, "GetFollowings", g.client.ListFollowings, userID, cursor, limit)
+}
+
+func (g *userGraphGRPC) GetFollowersPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
+	return g.list(ctx, "GetFollowers", g.client.ListFollowers, userID, cursor, limit)
+}
+
+type listFunc func(ctx context.Context, in *usergraphv1.ListRelationsRequest, opts ...grpc.CallOption) (*usergraphv1.ListRelationsResponse, error)
+
+func (g *userGraphGRPC) list(ctx context.Context, op string, call listFunc, userID string, cursor string, limit int) ([]string, string, error) {
+	request := &usergraphv1.ListRelationsRequest{
+		UserId: userID,
+		Cursor: cursor,
+		Limit:  int32(limit),
+	}
+
+	var response *usergraphv1.ListRelationsResponse
+	err := g.caller.Call(ctx, op, func(ctx context.Context) error {
+		var err error
+		response, err = call(ctx, request)
+		return classify(err)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.GetUserIds(), response.GetNextCursor(), nil
+}
+
+// classify marks the status codes a retry can fix as transient. The rest,
+// such as NotFound or InvalidArgument, fail the call straight away.
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal:
+		return userGraphHttp.Transient(err)
+	default:
+		return fmt.Errorf("user graph responded %w", err)
+	}
+}
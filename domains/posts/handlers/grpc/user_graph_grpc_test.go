@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"bootcamp-content-interaction-service/config"
+	userGraphHttp "bootcamp-content-interaction-service/domains/posts/handlers/http"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestUserGraph(t *testing.T) (*FakeUserGraphServer, userGraphHttp.UserGraphService) {
+	t.Helper()
+
+	fake := NewFakeUserGraphServer()
+	t.Cleanup(fake.Stop)
+
+	conn, err := fake.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return fake, NewUserGraphGRPC(conn, config.UserGraph{
+		Timeout:         time.Second,
+		MaxRetries:      2,
+		RetryBaseDelay:  time.Millisecond,
+		RetryMaxDelay:   5 * time.Millisecond,
+		BreakerFailures: 100,
+	})
+}
+
+func TestUserGraphGRPCPages(t *testing.T) {
+	fake, graph := newTestUserGraph(t)
+	fake.SetFollowings("u1", "a", "b", "c", "d", "e")
+
+	ids, next, err := graph.GetFollowingsPage(context.Background(), "u1", "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b"}) || next == "" {
+		t.Fatalf("first page = %v, %q, want [a b] and a cursor", ids, next)
+	}
+
+	ids, next, err = graph.GetFollowingsPage(context.Background(), "u1", next, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"c", "d"}) || next == "" {
+		t.Fatalf("second page = %v, %q, want [c d] and a cursor", ids, next)
+	}
+
+	ids, next, err = graph.GetFollowingsPage(context.Background(), "u1", next, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"e"}) || next != "" {
+		t.Fatalf("last page = %v, %q, want [e] and no cursor", ids, next)
+	}
+}
+
+func TestUserGraphGRPCCollectsEveryPage(t *testing.T) {
+	fake, graph := newTestUserGraph(t)
+	fake.SetFollowers("u1", "a", "b", "c")
+
+	smallPages := func(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
+		return graph.GetFollowersPage(ctx, userID, cursor, 1)
+	}
+	ids, err := userGraphHttp.CollectPages(context.Background(), "u1", smallPages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b", "c"}) {
+		t.Errorf("ids = %v, want [a b c]", ids)
+	}
+	if got := fake.Calls(); got != 3 {
+		t.Errorf("fake called %d times, want 3", got)
+	}
+
+	all, err := graph.GetFollowers(context.Background(), "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(all, []string{"a", "b", "c"}) {
+		t.Errorf("GetFollowers = %v, want [a b c]", all)
+	}
+}
+
+func TestUserGraphGRPCRetriesTransientCodes(t *testing.T) {
+	for _, code := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal} {
+		t.Run(code.String(), func(t *testing.T) {
+			fake, graph := newTestUserGraph(t)
+			fake.SetFollowings("u1", "a")
+			fake.FailNext(code)
+
+			ids, err := graph.GetFollowings(context.Background(), "u1")
+			if err != nil {
+				t.Fatalf("err = %v, want success after a retry", err)
+			}
+			if !reflect.DeepEqual(ids, []string{"a"}) {
+				t.Errorf("ids = %v, want [a]", ids)
+			}
+			if got := fake.Calls(); got != 2 {
+				t.Errorf("fake called %d times, want 2", got)
+			}
+		})
+	}
+}
+
+func TestUserGraphGRPCDoesNotRetryPermanentCodes(t *testing.T) {
+	for _, code := range []codes.Code{codes.NotFound, codes.InvalidArgument, codes.PermissionDenied} {
+		t.Run(code.String(), func(t *testing.T) {
+			fake, graph := newTestUserGraph(t)
+			fake.FailNext(code)
+
+			_, err := graph.GetFollowings(context.Background(), "u1")
+			if err == nil {
+				t.Fatal("err = nil, want the failure")
+			}
+			if errors.Is(err, userGraphHttp.ErrUserGraphUnavailable) {
+				t.Errorf("err = %v, want a permanent failure not to count as unavailable", err)
+			}
+			if got := status.Code(err); got != code {
+				t.Errorf("status code = %s, want %s", got, code)
+			}
+			if got := fake.Calls(); got != 1 {
+				t.Errorf("fake called %d times, want 1", got)
+			}
+		})
+	}
+}
+
+func TestUserGraphGRPCReportsExhaustedRetriesAsUnavailable(t *testing.T) {
+	fake, graph := newTestUserGraph(t)
+	fake.FailNext(codes.Unavailable, codes.Unavailable, codes.Unavailable)
+
+	_, err := graph.GetFollowings(context.Background(), "u1")
+	if !errors.Is(err, userGraphHttp.ErrUserGraphUnavailable) {
+		t.Fatalf("err = %v, want ErrUserGraphUnavailable", err)
+	}
+	if got := status.Code(err); got != codes.Unavailable {
+		t.Errorf("status code = %s, want the last failure's code", got)
+	}
+	if got := fake.Calls(); got != 3 {
+		t.Errorf("fake called %d times, want 3", got)
+	}
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/media"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/models/requests"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func (handler *PostHttp) AddImages(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.AddImagesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.postUc.AddImages(ctx, c.Param("id"), &req)
+	if err != nil {
+		writeEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *PostHttp) ReorderImages(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.ReorderImagesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.postUc.ReorderImages(ctx, c.Param("id"), &req)
+	if err != nil {
+		writeEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *PostHttp) UpdateImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.UpdateImageRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.postUc.UpdateImage(ctx, c.Param("id"), c.Param("media_id"), &req)
+	if err != nil {
+		writeEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RemoveImage takes the expected revision from ?revision=, since DELETE
+// requests carry no body.
+func (handler *PostHttp) RemoveImage(c *gin.Context) {
+	revision := 0
+	if raw := c.Query("revision"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "revision must be a non-negative integer"})
+			return
+		}
+		revision = parsed
+	}
+
+	result, err := handler.postUc.RemoveImage(c.Request.Context(), c.Param("id"), c.Param("media_id"), revision)
+	if err != nil {
+		writeEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeEditError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, posts.ErrInvalidPost), errors.Is(err, media.ErrUploadNotFound):
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, posts.ErrForbidden):
+		c.JSON(http.StatusForbidden, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, posts.ErrPostNotFound), errors.Is(err, posts.ErrImageNotFound):
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, posts.ErrPostConflict):
+		c.JSON(http.StatusConflict, responses.BasicResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+	}
+}
@@ -1,14 +1,17 @@
 package http
 
 import (
+	"bootcamp-content-interaction-service/domains/media"
 	"bootcamp-content-interaction-service/domains/posts"
 	"bootcamp-content-interaction-service/domains/posts/models/requests"
+	postResponses "bootcamp-content-interaction-service/domains/posts/models/responses"
+	"bootcamp-content-interaction-service/domains/relations"
 	"bootcamp-content-interaction-service/shared/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"errors"
 	"strconv"
 
 	"net/http"
-	"os"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -34,24 +37,9 @@ func (handler *PostHttp) UpdatePost(c *gin.Context) {
         return
     }
 
-    formData, err := c.MultipartForm()
-    if err == nil {
-        var imageURLs []string
-        for _, file := range formData.File["images"] {
-            timestamp := time.Now().Format("20060102150405")
-            savePath := "storage/post/" + timestamp + "_" + file.Filename
-            if err := c.SaveUploadedFile(file, savePath); err != nil {
-                c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: "File upload error: " + err.Error()})
-                return
-            }
-            imageURLs = append(imageURLs, savePath)
-        }
-        form.ImageURLs = imageURLs
-    }
-
     result, err := handler.postUc.UpdatePost(ctx, postID, &form)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+        writeEditError(c, err)
         return
     }
 
@@ -78,6 +66,10 @@ func (handler *PostHttp) ViewPostById(c *gin.Context) {
     postID := c.Param("id")
     result, err := handler.postUc.ViewPostById(ctx, postID)
 
+    if errors.Is(err, posts.ErrPostNotFound) {
+        c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+        return
+    }
     if err != nil {
         c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
         return
@@ -99,17 +91,95 @@ func (handler *PostHttp) ViewAllPostByUserId(c *gin.Context) {
     c.JSON(http.StatusOK, result)
 }
 
+// ViewAllPost serves the explore feed: recent posts ranked by engagement,
+// personalised when the caller is signed in.
 func (handler *PostHttp) ViewAllPost(c *gin.Context) {
-    ctx := c.Request.Context()
+	ctx := c.Request.Context()
 
-    result, err := handler.postUc.ViewAllPost(ctx)
+	limit, offset, ok := parsePage(c)
+	if !ok {
+		return
+	}
 
-    if err != nil {
-        c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
-        return
-    }
+	result, err := handler.postUc.ViewExplore(ctx, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
 
-    c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: result})
+}
+
+func (handler *PostHttp) AddSave(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := handler.postUc.AddSave(ctx, c.Param("id"))
+	if err != nil {
+		writeSaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: "Post " + c.Param("id") + " saved"})
+}
+
+func (handler *PostHttp) RemoveSave(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := handler.postUc.RemoveSave(ctx, c.Param("id"))
+	if err != nil {
+		writeSaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: "Post " + c.Param("id") + " unsaved"})
+}
+
+func (handler *PostHttp) ViewSavedPost(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	limit, offset, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	result, err := handler.postUc.ViewSavedPost(ctx, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: result})
+}
+
+func writeSaveError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, posts.ErrInvalidPost):
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, relations.ErrBlocked):
+		c.JSON(http.StatusForbidden, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, posts.ErrPostNotFound), errors.Is(err, posts.ErrSaveNotFound):
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+	}
+}
+
+// parsePage reads the page and limit query parameters and writes a 400 when
+// either is invalid.
+func parsePage(c *gin.Context) (int, int, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "Invalid page parameter"})
+		return 0, 0, false
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "Invalid limit parameter"})
+		return 0, 0, false
+	}
+
+	return limit, (page - 1) * limit, true
 }
 
 func (handler *PostHttp) CreatePost(c *gin.Context) {
@@ -121,48 +191,18 @@ func (handler *PostHttp) CreatePost(c *gin.Context) {
         return
     }
 
-    formData, err := c.MultipartForm()
-    if err != nil {
-        c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "Invalid multipart form: " + err.Error()})
-        return
-    }
-
-    files := formData.File["images"]
-    if len(files) == 0 {
-        c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "At least one image is required"})
-        return
-    }
-
-    var imagePaths []string
-    for _, file := range files {
-        timestamp := time.Now().Format("20060102150405")
-        savePath := "storage/post/" + timestamp + "_" + file.Filename
-        if err := c.SaveUploadedFile(file, savePath); err != nil {
-            for _, path := range imagePaths {
-                _ = os.Remove(path)
-            }
-            c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: "Failed to save image: " + err.Error()})
-            return
-        }
-        imagePaths = append(imagePaths, savePath)
-    }
-
-    form.ImageURLs = imagePaths
-
     validate := validator.New()
     if err := validate.StructCtx(ctx, form); err != nil {
-        for _, path := range imagePaths {
-            _ = os.Remove(path)
-        }
         c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
         return
     }
 
     result, err := handler.postUc.CreatePost(ctx, &form)
+    if errors.Is(err, media.ErrUploadNotFound) || errors.Is(err, posts.ErrInvalidPost) {
+        c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+        return
+    }
     if err != nil {
-        for _, path := range imagePaths {
-            _ = os.Remove(path)
-        }
         c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
         return
     }
@@ -170,32 +210,84 @@ func (handler *PostHttp) CreatePost(c *gin.Context) {
     c.JSON(http.StatusCreated, result)
 }
 
+// ViewPersonalFeed serves the feed of followed accounts, newest first by
+// default or by score with ?mode=ranked. Ranked pages are walked with the
+// cursor returned in X-Feed-Next-Cursor, and X-Feed-Caught-Up tells the
+// client the user has seen every recent post. The feed, seen posts and
+// ranking are private, so only the signed-in user's own feed is served.
 func (handler *PostHttp) ViewPersonalFeed(c *gin.Context) {
 	ctx := c.Request.Context()
-	userId := c.Param("id")
-
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "Invalid page parameter"})
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	userId := user.UserId
+	if id := c.Param("id"); id != "" && id != userId {
+		c.JSON(http.StatusForbidden, responses.BasicResponse{Error: "Feed belongs to someone else"})
 		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 {
-		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "Invalid limit parameter"})
+	limit, offset, ok := parsePage(c)
+	if !ok {
 		return
 	}
 
-	offset := (page - 1) * limit
+	var page *postResponses.FeedPage
 
-	result, err := handler.postUc.ViewPostByUserId(ctx, userId, limit, offset)
+	mode := c.DefaultQuery("mode", util.FEED_CHRONOLOGICAL)
+	switch mode {
+	case util.FEED_CHRONOLOGICAL:
+		page, err = handler.postUc.ViewPostByUserId(ctx, userId, limit, offset)
+	case util.FEED_RANKED:
+		page, err = handler.postUc.ViewRankedFeed(ctx, userId, c.Query("cursor"), limit)
+	default:
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: "Invalid mode parameter"})
+		return
+	}
+	if errors.Is(err, posts.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, responses.BasicResponse{Data: result})
+	c.Header("X-Feed-Mode", mode)
+	if page.NextCursor != "" {
+		c.Header("X-Feed-Next-Cursor", page.NextCursor)
+	}
+	if page.Degraded {
+		c.Header("X-Feed-Degraded", "true")
+	}
+	if page.CaughtUp {
+		c.Header("X-Feed-Caught-Up", "true")
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: page.Posts})
+}
+
+func (handler *PostHttp) ReportImpressions(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.ImpressionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	if err := handler.postUc.ReportImpressions(ctx, &req); err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
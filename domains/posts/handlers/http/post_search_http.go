@@ -0,0 +1,43 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/models/requests"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// SearchPosts serves ?q= searches over post captions. ?mode=prefix matches
+// the last word as a prefix, and later pages are fetched with the
+// next_cursor of the previous one.
+func (handler *PostHttp) SearchPosts(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.SearchRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.postUc.SearchPosts(ctx, &req)
+	if errors.Is(err, posts.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
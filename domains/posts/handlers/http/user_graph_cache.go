@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// UserGraphInvalidator drops cached relations of users whose follows changed.
+type UserGraphInvalidator interface {
+	Invalidate(ctx context.Context, userIDs ...string) error
+}
+
+type cachedPage struct {
+	IDs        []string `json:"ids"`
+	NextCursor string   `json:"next_cursor"`
+}
+
+// userGraphCache keeps user graph pages in Redis for a short TTL. Every key
+// embeds a per-user version, so invalidating a user is a single INCR and the
+// stale pages simply expire. The version key itself never expires: if it did,
+// the next INCR would restart at 1 and reach pages cached under that version.
+type userGraphCache struct {
+	next       UserGraphService
+	redisCache *redis.Client
+	ttl        time.Duration
+	logger     util.Logger
+}
+
+type CachedUserGraphService interface {
+	UserGraphService
+	UserGraphInvalidator
+}
+
+func NewCachedUserGraph(next UserGraphService, redisClient *redis.Client, ttl time.Duration, logger util.Logger) CachedUserGraphService {
+	return &userGraphCache{
+		next:       next,
+		redisCache: redisClient,
+		ttl:        ttl,
+		logger:     logger,
+	}
+}
+
+func (c *userGraphCache) GetFollowings(ctx context.Context, userID string) ([]string, error) {
+	return CollectPages(ctx, userID, c.GetFollowingsPage)
+}
+
+func (c *userGraphCache) GetFollowers(ctx context.Context, userID string) ([]string, error) {
+	return CollectPages(ctx, userID, c.GetFollowersPage)
+}
+
+func (c *userGraphCache) GetFollowingsPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
+	return c.page(ctx, "followings", userID, cursor, limit, c.next.GetFollowingsPage)
+}
+
+func (c *userGraphCache) GetFollowersPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
+	return c.page(ctx, "followers", userID, cursor, limit, c.next.GetFollowersPage)
+}
+
+func (c *userGraphCache) Invalidate(ctx context.Context, userIDs ...string) error {
+	pipe := c.redisCache.Pipeline()
+	for _, userID := range userIDs {
+		pipe.Incr(ctx, versionKey(userID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logger.Error("Failed to invalidate user graph cache",
+			zap.Strings("user_ids", userIDs),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	c.logger.Info("Invalidated user graph cache", zap.Strings("user_ids", userIDs))
+	return nil
+}
+
+func (c *userGraphCache) page(ctx context.Context, relation string, userID string, cursor string, limit int, fetch PageFunc) ([]string, string, error) {
+	version, err := c.redisCache.Get(ctx, versionKey(userID)).Result()
+	if err == redis.Nil {
+		version = "0"
+	} else if err != nil {
+		c.logger.Error("Redis GET operation failed", zap.String("user_id", userID), zap.Error(err))
+		return fetch(ctx, userID, cursor, limit)
+	}
+
+	cacheKey := fmt.Sprintf("user_graph:%s:%s:v%s:%s:%d", relation, userID, version, cursor, limit)
+
+	cached, err := c.redisCache.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var page cachedPage
+		if err := json.Unmarshal([]byte(cached), &page); err == nil {
+			return page.IDs, page.NextCursor, nil
+		}
+	} else if err != redis.Nil {
+		c.logger.Error("Redis GET operation failed", zap.String("cache_key", cacheKey), zap.Error(err))
+	}
+
+	ids, next, err := fetch(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	bytes, err := json.Marshal(cachedPage{IDs: ids, NextCursor: next})
+	if err == nil {
+		err = c.redisCache.Set(ctx, cacheKey, bytes, c.ttl).Err()
+	}
+	if err != nil {
+		c.logger.Error("Failed to set cache", zap.String("cache_key", cacheKey), zap.Error(err))
+	}
+
+	return ids, next, nil
+}
+
+func versionKey(userID string) string {
+	return "user_graph:version:" + userID
+}
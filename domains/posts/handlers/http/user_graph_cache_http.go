@@ -0,0 +1,43 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/posts/models/requests"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type UserGraphCacheHttp struct {
+	invalidator UserGraphInvalidator
+}
+
+func NewUserGraphCacheHttp(invalidator UserGraphInvalidator) *UserGraphCacheHttp {
+	return &UserGraphCacheHttp{
+		invalidator: invalidator,
+	}
+}
+
+// Invalidate is called by the user graph service whenever a follow between
+// two users changes. It should list both users of the relation.
+func (handler *UserGraphCacheHttp) Invalidate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request requests.InvalidateUserGraphRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err := validator.New().StructCtx(ctx, request); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	if err := handler.invalidator.Invalidate(ctx, request.UserIDs...); err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
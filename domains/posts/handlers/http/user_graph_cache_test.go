@@ -0,0 +1,215 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/shared/util"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis speaks just enough RESP2 for the cache: GET, SET with EX/PX,
+// INCR and EXPIRE. Keys expire against a clock the test moves by hand.
+type fakeRedis struct {
+	mu      sync.Mutex
+	now     time.Time
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, *redis.Client) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	f := &fakeRedis{
+		now:     time.Now(),
+		values:  map[string]string{},
+		expires: map[string]time.Time{},
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{Addr: ln.Addr().String(), DisableIdentity: true})
+	t.Cleanup(func() { client.Close() })
+	return f, client
+}
+
+func (f *fakeRedis) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for key, at := range f.expires {
+		if !f.now.Before(at) {
+			delete(f.values, key)
+			delete(f.expires, key)
+		}
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		value, ok := f.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		f.values[args[1]] = args[2]
+		delete(f.expires, args[1])
+		if len(args) == 5 {
+			n, _ := strconv.Atoi(args[4])
+			unit := time.Second
+			if strings.EqualFold(args[3], "px") {
+				unit = time.Millisecond
+			}
+			f.expires[args[1]] = f.now.Add(time.Duration(n) * unit)
+		}
+		return "+OK\r\n"
+	case "INCR":
+		n, _ := strconv.Atoi(f.values[args[1]])
+		n++
+		f.values[args[1]] = strconv.Itoa(n)
+		return fmt.Sprintf(":%d\r\n", n)
+	case "EXPIRE":
+		if _, ok := f.values[args[1]]; !ok {
+			return ":0\r\n"
+		}
+		n, _ := strconv.Atoi(args[2])
+		f.expires[args[1]] = f.now.Add(time.Duration(n) * time.Second)
+		return ":1\r\n"
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+type fakeFollowerGraph struct {
+	UserGraphService
+	followers []string
+	fetches   int
+}
+
+func (g *fakeFollowerGraph) GetFollowersPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
+	g.fetches++
+	return g.followers, "", nil
+}
+
+func TestUserGraphCacheServesPagesUntilInvalidated(t *testing.T) {
+	_, client := startFakeRedis(t)
+	graph := &fakeFollowerGraph{followers: []string{"a"}}
+	cache := NewCachedUserGraph(graph, client, time.Minute, util.NewNopLogger())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := cache.GetFollowersPage(ctx, "u1", "", 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if graph.fetches != 1 {
+		t.Fatalf("fetches = %d, want 1", graph.fetches)
+	}
+
+	graph.followers = []string{"a", "b"}
+	if err := cache.Invalidate(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	ids, _, err := cache.GetFollowersPage(ctx, "u1", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("ids = %v, want [a b]", ids)
+	}
+}
+
+func TestUserGraphCacheInvalidatesAfterVersionKeyWouldExpire(t *testing.T) {
+	redisServer, client := startFakeRedis(t)
+	graph := &fakeFollowerGraph{followers: []string{"a"}}
+	cache := NewCachedUserGraph(graph, client, time.Minute, util.NewNopLogger())
+	ctx := context.Background()
+
+	if err := cache.Invalidate(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cache a page late in the version's life, then invalidate again once
+	// that page is still live but long after the last invalidation.
+	redisServer.advance(110 * time.Second)
+	if _, _, err := cache.GetFollowersPage(ctx, "u1", "", 10); err != nil {
+		t.Fatal(err)
+	}
+	redisServer.advance(40 * time.Second)
+
+	graph.followers = []string{"a", "b"}
+	if err := cache.Invalidate(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	ids, _, err := cache.GetFollowersPage(ctx, "u1", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("ids = %v, want [a b], a page cached before the invalidation was reused", ids)
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/sony/gobreaker"
+)
+
+// transientError marks failures worth retrying and counting against the
+// breaker: network errors, timeouts, throttling and server errors.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+// Transient marks err as retryable for UserGraphCaller.Call.
+func Transient(err error) error {
+	return &transientError{err: err}
+}
+
+// UserGraphCaller holds the resilience policy shared by every user graph
+// transport: it bounds each attempt with conf.Timeout, retries transient
+// failures with jittered backoff and trips a circuit breaker after
+// conf.BreakerFailures consecutive failed calls.
+type UserGraphCaller struct {
+	breaker *gobreaker.CircuitBreaker
+	conf    config.UserGraph
+}
+
+func NewUserGraphCaller(conf config.UserGraph) *UserGraphCaller {
+	conf = conf.WithDefaults()
+
+	return &UserGraphCaller{
+		conf: conf,
+		breaker: gobreaker.NewCircuitBreaker(gobreaker.Settings{
+			Name:        "user-graph",
+			MaxRequests: 1,
+			Timeout:     conf.BreakerOpenTimeout,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= conf.BreakerFailures
+			},
+			IsSuccessful: func(err error) bool {
+				var transient *transientError
+				return err == nil || !errors.As(err, &transient)
+			},
+		}),
+	}
+}
+
+// Call runs one logical call through the breaker. The retries happen inside
+// it, so the breaker counts a call once no matter how many attempts it took.
+// Breaker rejections and exhausted retries come back as a
+// UserGraphUnavailableError.
+func (c *UserGraphCaller) Call(ctx context.Context, op string, attempt func(ctx context.Context) error) error {
+	_, err := c.breaker.Execute(func() (interface{}, error) {
+		return nil, c.retry(ctx, attempt)
+	})
+
+	var transient *transientError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gobreaker.ErrOpenState), errors.Is(err, gobreaker.ErrTooManyRequests):
+		return &UserGraphUnavailableError{Op: op, Cause: err}
+	case errors.As(err, &transient):
+		return &UserGraphUnavailableError{Op: op, Cause: transient.err}
+	default:
+		return err
+	}
+}
+
+func (c *UserGraphCaller) retry(ctx context.Context, attempt func(ctx context.Context) error) error {
+	var err error
+	for n := 0; n <= c.conf.MaxRetries; n++ {
+		if n > 0 {
+			timer := time.NewTimer(c.backoff(n))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+
+		err = c.once(ctx, attempt)
+
+		var transient *transientError
+		if err == nil || !errors.As(err, &transient) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *UserGraphCaller) once(ctx context.Context, attempt func(ctx context.Context) error) error {
+	attemptCtx, cancel := context.WithTimeout(ctx, c.conf.Timeout)
+	defer cancel()
+	return attempt(attemptCtx)
+}
+
+// backoff doubles RetryBaseDelay per retry and picks a random point in that
+// window ("full jitter") so clients do not retry in lockstep.
+func (c *UserGraphCaller) backoff(n int) time.Duration {
+	window := c.conf.RetryBaseDelay << (n - 1)
+	if window <= 0 || window > c.conf.RetryMaxDelay {
+		window = c.conf.RetryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(window)) + 1)
+}
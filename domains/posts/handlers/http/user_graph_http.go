@@ -1,41 +1,87 @@
 package http
 
 import (
+	"bootcamp-content-interaction-service/config"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
+const maxUserGraphBody = 10 << 20
+
+// ErrUserGraphUnavailable is matched by every UserGraphUnavailableError, so
+// callers can degrade with a plain errors.Is check.
+var ErrUserGraphUnavailable = errors.New("user graph service unavailable")
+
+// UserGraphUnavailableError is returned when the circuit breaker is open or
+// a call kept failing after its retries. Other failures, such as a 4xx
+// response, are returned as ordinary errors.
+type UserGraphUnavailableError struct {
+	Op    string
+	Cause error
+}
+
+func (e *UserGraphUnavailableError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", ErrUserGraphUnavailable, e.Op, e.Cause)
+}
+
+func (e *UserGraphUnavailableError) Unwrap() []error {
+	return []error{ErrUserGraphUnavailable, e.Cause}
+}
+
 type userGraphHTTP struct {
 	BaseURL string
+	client  *http.Client
+	caller  *UserGraphCaller
 }
 
+// UserGraphService reads follow relations from the user graph service. The
+// Page methods return one page and the cursor of the next, which is empty on
+// the last page. GetFollowers and GetFollowings walk every page.
 type UserGraphService interface {
-	GetFollowings(userID string) ([]string, error)
-	GetFollowers(userID string) ([]string, error)
+	GetFollowings(ctx context.Context, userID string) ([]string, error)
+	GetFollowers(ctx context.Context, userID string) ([]string, error)
+	GetFollowingsPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error)
+	GetFollowersPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error)
 }
 
-func NewUserGraphHTTP(baseURL string) UserGraphService {
-	return &userGraphHTTP{BaseURL: baseURL}
+const userGraphPageSize = 1000
+
+// NewUserGraphHTTP builds the JSON over HTTP client. Every call goes
+// through a UserGraphCaller built from conf.
+func NewUserGraphHTTP(baseURL string, client *http.Client, conf config.UserGraph) UserGraphService {
+	return &userGraphHTTP{
+		BaseURL: baseURL,
+		client:  client,
+		caller:  NewUserGraphCaller(conf),
+	}
 }
 
-func (g *userGraphHTTP) GetFollowings(userID string) ([]string, error) {
-	url := fmt.Sprintf("%s/api/v1/relations/%s/followings", g.BaseURL,userID)
+func (g *userGraphHTTP) GetFollowings(ctx context.Context, userID string) ([]string, error) {
+	return CollectPages(ctx, userID, g.GetFollowingsPage)
+}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+func (g *userGraphHTTP) GetFollowers(ctx context.Context, userID string) ([]string, error) {
+	return CollectPages(ctx, userID, g.GetFollowersPage)
+}
 
+func (g *userGraphHTTP) GetFollowingsPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
 	var result struct {
 		Followings []struct {
 			FollowingID string `json:"following_id"`
 		} `json:"followings"`
+		NextCursor string `json:"next_cursor"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if err := g.caller.Call(ctx, "GetFollowings", func(ctx context.Context) error {
+		return g.getJSON(ctx, g.relationsURL(userID, "followings", cursor, limit), &result)
+	}); err != nil {
+		return nil, "", err
 	}
 
 	var followingIDs []string
@@ -43,26 +89,21 @@ func (g *userGraphHTTP) GetFollowings(userID string) ([]string, error) {
 		followingIDs = append(followingIDs, f.FollowingID)
 	}
 
-	return followingIDs, nil
+	return followingIDs, result.NextCursor, nil
 }
 
-func (g *userGraphHTTP) GetFollowers(userID string) ([]string, error) {
-	url := fmt.Sprintf("%s/api/v1/relations/%s/followers", g.BaseURL, userID)
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
+func (g *userGraphHTTP) GetFollowersPage(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error) {
 	var result struct {
 		Followers []struct {
 			FollowerID string `json:"follower_id"`
 		} `json:"followers"`
+		NextCursor string `json:"next_cursor"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if err := g.caller.Call(ctx, "GetFollowers", func(ctx context.Context) error {
+		return g.getJSON(ctx, g.relationsURL(userID, "followers", cursor, limit), &result)
+	}); err != nil {
+		return nil, "", err
 	}
 
 	var followerIDs []string
@@ -70,5 +111,65 @@ func (g *userGraphHTTP) GetFollowers(userID string) ([]string, error) {
 		followerIDs = append(followerIDs, f.FollowerID)
 	}
 
-	return followerIDs, nil
-}
\ No newline at end of file
+	return followerIDs, result.NextCursor, nil
+}
+
+func (g *userGraphHTTP) relationsURL(userID string, relation string, cursor string, limit int) string {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	return fmt.Sprintf("%s/api/v1/relations/%s/%s?%s", g.BaseURL, url.PathEscape(userID), relation, query.Encode())
+}
+
+// PageFunc fetches one page of a relation, as the Page methods do.
+type PageFunc func(ctx context.Context, userID string, cursor string, limit int) ([]string, string, error)
+
+// CollectPages walks every page of a relation and returns all ids.
+func CollectPages(ctx context.Context, userID string, page PageFunc) ([]string, error) {
+	var ids []string
+	cursor := ""
+	for {
+		batch, next, err := page(ctx, userID, cursor, userGraphPageSize)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, batch...)
+		if next == "" || next == cursor {
+			return ids, nil
+		}
+		cursor = next
+	}
+}
+
+func (g *userGraphHTTP) getJSON(ctx context.Context, endpoint string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return Transient(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, maxUserGraphBody))
+		return Transient(fmt.Errorf("user graph responded %s", resp.Status))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("user graph responded %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxUserGraphBody)).Decode(target); err != nil {
+		if ctx.Err() != nil {
+			return Transient(ctx.Err())
+		}
+		return fmt.Errorf("invalid user graph response: %w", err)
+	}
+
+	return nil
+}
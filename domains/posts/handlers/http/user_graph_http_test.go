@@ -0,0 +1,173 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+)
+
+func testUserGraphConf() config.UserGraph {
+	return config.UserGraph{
+		Timeout:            100 * time.Millisecond,
+		MaxRetries:         2,
+		RetryBaseDelay:     time.Millisecond,
+		RetryMaxDelay:      5 * time.Millisecond,
+		BreakerFailures:    3,
+		BreakerOpenTimeout: time.Minute,
+	}
+}
+
+// statusThenOK answers the first len(statuses) requests with those statuses
+// and every later one with a single following.
+func statusThenOK(hits *int32, statuses ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(hits, 1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		fmt.Fprint(w, `{"followings":[{"following_id":"u2"}]}`)
+	}
+}
+
+func TestUserGraphHTTPTimesOutEachAttempt(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	graph := NewUserGraphHTTP(server.URL, server.Client(), testUserGraphConf())
+
+	start := time.Now()
+	_, err := graph.GetFollowings(context.Background(), "u1")
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrUserGraphUnavailable) {
+		t.Fatalf("err = %v, want ErrUserGraphUnavailable", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("server hit %d times, want 3", got)
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("call took %s, want each attempt cut off after the timeout", elapsed)
+	}
+}
+
+func TestUserGraphHTTPRetriesTransientResponses(t *testing.T) {
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var hits int32
+			server := httptest.NewServer(statusThenOK(&hits, status, status))
+			defer server.Close()
+
+			graph := NewUserGraphHTTP(server.URL, server.Client(), testUserGraphConf())
+
+			ids, err := graph.GetFollowings(context.Background(), "u1")
+			if err != nil {
+				t.Fatalf("err = %v, want success after retries", err)
+			}
+			if !reflect.DeepEqual(ids, []string{"u2"}) {
+				t.Errorf("ids = %v, want [u2]", ids)
+			}
+			if got := atomic.LoadInt32(&hits); got != 3 {
+				t.Errorf("server hit %d times, want 3", got)
+			}
+		})
+	}
+}
+
+func TestUserGraphHTTPDoesNotRetryClientErrors(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(statusThenOK(&hits, http.StatusNotFound))
+	defer server.Close()
+
+	graph := NewUserGraphHTTP(server.URL, server.Client(), testUserGraphConf())
+
+	_, err := graph.GetFollowings(context.Background(), "u1")
+	if err == nil {
+		t.Fatal("err = nil, want the 404")
+	}
+	if errors.Is(err, ErrUserGraphUnavailable) {
+		t.Errorf("err = %v, want a 4xx not to count as unavailable", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("server hit %d times, want 1", got)
+	}
+}
+
+func TestUserGraphHTTPBreakerOpensAfterFailures(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	conf := testUserGraphConf()
+	conf.MaxRetries = 1
+	conf.BreakerFailures = 2
+	graph := NewUserGraphHTTP(server.URL, server.Client(), conf)
+
+	for i := 0; i < 2; i++ {
+		if _, err := graph.GetFollowings(context.Background(), "u1"); !errors.Is(err, ErrUserGraphUnavailable) {
+			t.Fatalf("call %d: err = %v, want ErrUserGraphUnavailable", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 4 {
+		t.Fatalf("server hit %d times, want 4", got)
+	}
+
+	_, err := graph.GetFollowings(context.Background(), "u1")
+	if !errors.Is(err, ErrUserGraphUnavailable) || !errors.Is(err, gobreaker.ErrOpenState) {
+		t.Fatalf("err = %v, want an open breaker reported as unavailable", err)
+	}
+	var unavailable *UserGraphUnavailableError
+	if !errors.As(err, &unavailable) || unavailable.Op != "GetFollowings" {
+		t.Errorf("err = %#v, want a UserGraphUnavailableError for GetFollowings", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 4 {
+		t.Errorf("server hit %d times with the breaker open, want 4", got)
+	}
+}
+
+func TestUserGraphHTTPWalksPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/relations/u1/followers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"followers":[{"follower_id":"a"},{"follower_id":"b"}],"next_cursor":"c1"}`)
+		case "c1":
+			fmt.Fprint(w, `{"followers":[{"follower_id":"c"}]}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	graph := NewUserGraphHTTP(server.URL, server.Client(), testUserGraphConf())
+
+	ids, err := graph.GetFollowers(context.Background(), "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b", "c"}) {
+		t.Errorf("ids = %v, want [a b c]", ids)
+	}
+}
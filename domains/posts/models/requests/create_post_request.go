@@ -1,7 +1,10 @@
 package requests
 
 type CreatePostRequest struct {
-    Caption   string   `form:"caption" validate:"required"`
-    Tags      []string `form:"tags"`
-    ImageURLs []string
+    Caption   string   `form:"caption" json:"caption" validate:"required"`
+    Tags      []string `form:"tags" json:"tags"`
+    MediaIDs  []string `form:"media_ids" json:"media_ids" validate:"required,min=1,unique,dive,uuid"`
+    // AltTexts describes the media for screen readers, in the order of
+    // MediaIDs. Items past its end, or left empty, have no alt text.
+    AltTexts  []string `form:"alt_texts" json:"alt_texts" validate:"dive,max=1000"`
 }
\ No newline at end of file
@@ -0,0 +1,5 @@
+package requests
+
+type ImpressionRequest struct {
+	PostIDs []string `json:"post_ids" validate:"required,min=1,max=200,dive,uuid"`
+}
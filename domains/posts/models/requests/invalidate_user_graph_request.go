@@ -0,0 +1,5 @@
+package requests
+
+type InvalidateUserGraphRequest struct {
+	UserIDs []string `json:"user_ids" validate:"required,min=1,max=100,dive,required"`
+}
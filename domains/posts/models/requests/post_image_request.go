@@ -0,0 +1,20 @@
+package requests
+
+// Revision, when set, must match the post's current revision; the change is
+// rejected otherwise so a client never overwrites edits it has not seen.
+
+type AddImagesRequest struct {
+	MediaIDs []string `json:"media_ids" validate:"required,min=1,unique,dive,uuid"`
+	AltTexts []string `json:"alt_texts" validate:"dive,max=1000"`
+	Revision int      `json:"revision" validate:"min=0"`
+}
+
+type ReorderImagesRequest struct {
+	MediaIDs []string `json:"media_ids" validate:"required,min=1,unique,dive,uuid"`
+	Revision int      `json:"revision" validate:"min=0"`
+}
+
+type UpdateImageRequest struct {
+	AltText  string `json:"alt_text" validate:"max=1000"`
+	Revision int    `json:"revision" validate:"min=0"`
+}
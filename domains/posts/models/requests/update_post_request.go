@@ -1,7 +1,9 @@
 package requests
 
 type UpdatePostRequest struct {
-    Caption   string   `form:"caption" validate:"required"`
-    Tags      []string `form:"tags"`
-    ImageURLs []string
+    Caption   string   `form:"caption" json:"caption" validate:"required"`
+    Tags      []string `form:"tags" json:"tags"`
+    MediaIDs  []string `form:"media_ids" json:"media_ids"`
+    AltTexts  []string `form:"alt_texts" json:"alt_texts" validate:"dive,max=1000"`
+    Revision  int      `form:"revision" json:"revision"`
 }
\ No newline at end of file
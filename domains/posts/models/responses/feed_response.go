@@ -0,0 +1,10 @@
+package responses
+
+// FeedPage is one page of the personal feed. The handler sends Posts as the
+// body and the rest as X-Feed-* headers.
+type FeedPage struct {
+	Posts      []*PostResponse
+	NextCursor string
+	Degraded   bool
+	CaughtUp   bool
+}
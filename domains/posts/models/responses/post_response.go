@@ -10,8 +10,29 @@ type PostResponse struct {
 	ID		  uuid.UUID   `json:"id"`
 	UserID    uuid.UUID   `json:"user_id"`
 	ImageURLs []string    `json:"image_urls"`
+	ImageRenditions []map[string]string `json:"image_renditions"`
+	Images    []*ImageResponse `json:"images"`
 	Caption   string      `json:"caption"`
 	Tags      []string    `json:"tags"`
 	CreatedAt time.Time	  `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
+	Revision  int         `json:"revision"`
+	// AltTextComplete tells the author whether every item has alt text. It
+	// is only set in the author's own post listing.
+	AltTextComplete *bool `json:"alt_text_complete,omitempty"`
+}
+
+// ImageResponse is one item of a post's carousel, an image or a video. ID
+// is the media ID the item was uploaded as. For a video, URL is the video
+// itself and PosterURL the frame to show before it plays.
+type ImageResponse struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	URL        string            `json:"url"`
+	PosterURL  string            `json:"poster_url,omitempty"`
+	Renditions map[string]string `json:"renditions"`
+	AltText    string            `json:"alt_text"`
+	Width      int               `json:"width,omitempty"`
+	Height     int               `json:"height,omitempty"`
+	DurationMs int64             `json:"duration_ms,omitempty"`
 }
@@ -0,0 +1,7 @@
+package responses
+
+// PostSearchPage is one page of post search results, best match first.
+type PostSearchPage struct {
+	Items      []*PostResponse `json:"items"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
@@ -5,25 +5,85 @@ import (
 	"bootcamp-content-interaction-service/domains/posts/models/requests"
 	"bootcamp-content-interaction-service/domains/posts/models/responses"
 	sharedResponse "bootcamp-content-interaction-service/shared/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
 	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPostNotFound  = errors.New("post not found")
+	ErrSaveNotFound  = errors.New("post is not saved")
+	ErrInvalidPost   = errors.New("invalid post request")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrPostConflict  = errors.New("post was changed by another request")
+	ErrForbidden     = errors.New("post belongs to someone else")
+	ErrImageNotFound = errors.New("image not found in post")
 )
 
 type PostUseCase interface {
 	CreatePost(ctx context.Context, request *requests.CreatePostRequest) (*responses.PostResponse, error)
-	ViewAllPost(ctx context.Context) ([]*responses.PostResponse, error)
+	ViewExplore(ctx context.Context, limit int, offset int) ([]*responses.PostResponse, error)
 	ViewAllPostByUserId(ctx context.Context) ([]*responses.PostResponse, error)
 	ViewPostById(ctx context.Context, id string) (*responses.PostResponse, error)
 	DeletePost(ctx context.Context, id string) (*sharedResponse.BasicResponse, error)
 	UpdatePost(ctx context.Context, postId string, request *requests.UpdatePostRequest) (*responses.PostResponse, error)
-	ViewPostByUserId(ctx context.Context, userId string, limit int, offset int) ([]*responses.PostResponse, error)
+	ViewPostByUserId(ctx context.Context, userId string, limit int, offset int) (*responses.FeedPage, error)
+	ViewRankedFeed(ctx context.Context, userId string, cursor string, limit int) (*responses.FeedPage, error)
+	ReportImpressions(ctx context.Context, request *requests.ImpressionRequest) error
+	AddSave(ctx context.Context, postId string) error
+	RemoveSave(ctx context.Context, postId string) error
+	ViewSavedPost(ctx context.Context, limit int, offset int) ([]*responses.PostResponse, error)
+	AddImages(ctx context.Context, postId string, request *requests.AddImagesRequest) (*responses.PostResponse, error)
+	ReorderImages(ctx context.Context, postId string, request *requests.ReorderImagesRequest) (*responses.PostResponse, error)
+	UpdateImage(ctx context.Context, postId string, mediaId string, request *requests.UpdateImageRequest) (*responses.PostResponse, error)
+	RemoveImage(ctx context.Context, postId string, mediaId string, revision int) (*responses.PostResponse, error)
+	SearchPosts(ctx context.Context, request *requests.SearchRequest) (*responses.PostSearchPage, error)
 }
 
 type PostRepository interface {
 	SavePost(ctx context.Context, post *entities.Post) (*entities.Post, error)
-	FindAll(ctx context.Context) ([]*entities.Post, error)
 	FindAllByUserId(ctx context.Context, userId string) ([]*entities.Post, error)
 	FindById(ctx context.Context, id string) (*entities.Post, error)
 	DeletePost(ctx context.Context, id string) (error)
-	UpdatePost(ctx context.Context, post *entities.Post) (*entities.Post, error)
+	MutatePost(ctx context.Context, id string, mutate func(post *entities.Post) error) (*entities.Post, error)
 	FindByUserIDs(ctx context.Context, userIds []string, limit int, offset int) ([]*entities.Post, error)
-}
\ No newline at end of file
+	FindRecent(ctx context.Context, excludeUserIds []string, limit int, offset int) ([]*entities.Post, error)
+	InvalidateCache(ctx context.Context, postId string, userId string) error
+	FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error)
+	FindEngagementSince(ctx context.Context, since time.Time) ([]*entities.PostEngagement, error)
+	FindFeedCandidates(ctx context.Context, userIds []string, since time.Time, limit int) ([]*entities.PostEngagement, error)
+	FindAffinity(ctx context.Context, viewerId string, authorIds []string, since time.Time) (map[string]int64, error)
+	SearchPosts(ctx context.Context, search util.TextSearch) ([]*entities.Post, *util.ScoreCursor, error)
+}
+
+// FeedSnapshotRepository keeps ranked feed orders for cursor pagination.
+type FeedSnapshotRepository interface {
+	SaveSnapshot(ctx context.Context, userId string, postIds []string) (string, error)
+	FindSnapshotPage(ctx context.Context, userId string, token string, offset int, limit int) ([]string, bool, error)
+}
+
+type PostSaveRepository interface {
+	AddSave(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	RemoveSave(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error
+	FindSavedPosts(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entities.Post, error)
+}
+
+// ExploreRepository keeps the explore ranking of recent posts.
+type ExploreRepository interface {
+	AddPost(ctx context.Context, postId string, createdAt time.Time, weight float64) error
+	Engage(ctx context.Context, postId string, weight float64, at time.Time) error
+	RemovePost(ctx context.Context, postId string) error
+	TopPostIDs(ctx context.Context) ([]string, error)
+	IsEmpty(ctx context.Context) (bool, error)
+	Prune(ctx context.Context, now time.Time) (int64, error)
+	Rebase(ctx context.Context, now time.Time) (bool, error)
+}
+
+// SeenRepository remembers which posts a user has already been shown.
+type SeenRepository interface {
+	MarkSeen(ctx context.Context, userId string, postIds []string) error
+	FindSeen(ctx context.Context, userId string, postIds []string) (map[string]bool, error)
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	exploreScoresKey  = "explore:scores"
+	exploreCreatedKey = "explore:created"
+	exploreEpochKey   = "explore:epoch"
+)
+
+// The explore ranking uses forward decay: an engagement at time t adds
+// weight * 2^((t - epoch) / half_life) to the post's score. Ranking by that
+// sum equals ranking by engagement decayed to "now", because decaying every
+// score to the same instant divides them all by the same factor. The epoch
+// is moved forward now and then so the scores stay small.
+
+// engageScript adds a decayed weight to a post that is still in the window.
+var engageScript = redis.NewScript(`
+if not redis.call('ZSCORE', KEYS[2], ARGV[1]) then
+	return 0
+end
+local epoch = redis.call('GET', KEYS[3])
+if not epoch then
+	redis.call('SET', KEYS[3], ARGV[3])
+	epoch = ARGV[3]
+end
+local delta = tonumber(ARGV[2]) * math.pow(2, (tonumber(ARGV[3]) - tonumber(epoch)) / tonumber(ARGV[4]))
+redis.call('ZINCRBY', KEYS[1], delta, ARGV[1])
+return 1
+`)
+
+// addScript enters a post into the window once and gives it its base score.
+var addScript = redis.NewScript(`
+if redis.call('ZADD', KEYS[2], 'NX', ARGV[3], ARGV[1]) == 0 then
+	return 0
+end
+local epoch = redis.call('GET', KEYS[3])
+if not epoch then
+	redis.call('SET', KEYS[3], ARGV[3])
+	epoch = ARGV[3]
+end
+local delta = tonumber(ARGV[2]) * math.pow(2, (tonumber(ARGV[3]) - tonumber(epoch)) / tonumber(ARGV[4]))
+redis.call('ZINCRBY', KEYS[1], delta, ARGV[1])
+return 1
+`)
+
+// pruneScript drops posts created before ARGV[1] from both sets.
+var pruneScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', '(' .. ARGV[1])
+for _, id in ipairs(ids) do
+	redis.call('ZREM', KEYS[1], id)
+end
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', '(' .. ARGV[1])
+return #ids
+`)
+
+// rebaseScript moves the epoch to ARGV[1] once it is ARGV[2] seconds old,
+// scaling every score down by the decay between the two epochs.
+var rebaseScript = redis.NewScript(`
+local epoch = redis.call('GET', KEYS[3])
+if not epoch or tonumber(ARGV[1]) - tonumber(epoch) < tonumber(ARGV[2]) then
+	return 0
+end
+local factor = math.pow(2, (tonumber(epoch) - tonumber(ARGV[1])) / tonumber(ARGV[3]))
+local scores = redis.call('ZRANGE', KEYS[1], 0, -1, 'WITHSCORES')
+for i = 1, #scores, 2 do
+	redis.call('ZADD', KEYS[1], tonumber(scores[i + 1]) * factor, scores[i])
+end
+redis.call('SET', KEYS[3], ARGV[1])
+return 1
+`)
+
+type ExploreRepository struct {
+	redisCache *redis.Client
+	conf       config.Explore
+	logger     util.Logger
+}
+
+func NewExploreRepository(redisClient *redis.Client, conf config.Explore, logger util.Logger) posts.ExploreRepository {
+	return ExploreRepository{
+		redisCache: redisClient,
+		conf:       conf.WithDefaults(),
+		logger:     logger,
+	}
+}
+
+func (e ExploreRepository) AddPost(ctx context.Context, postId string, createdAt time.Time, weight float64) error {
+	if time.Since(createdAt) > e.conf.Window {
+		return nil
+	}
+	return e.run(ctx, addScript, postId, weight, createdAt)
+}
+
+func (e ExploreRepository) Engage(ctx context.Context, postId string, weight float64, at time.Time) error {
+	return e.run(ctx, engageScript, postId, weight, at)
+}
+
+func (e ExploreRepository) RemovePost(ctx context.Context, postId string) error {
+	pipe := e.redisCache.TxPipeline()
+	pipe.ZRem(ctx, exploreScoresKey, postId)
+	pipe.ZRem(ctx, exploreCreatedKey, postId)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (e ExploreRepository) TopPostIDs(ctx context.Context) ([]string, error) {
+	return e.redisCache.ZRevRange(ctx, exploreScoresKey, 0, int64(e.conf.Candidates-1)).Result()
+}
+
+func (e ExploreRepository) IsEmpty(ctx context.Context) (bool, error) {
+	count, err := e.redisCache.ZCard(ctx, exploreCreatedKey).Result()
+	if err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+func (e ExploreRepository) Prune(ctx context.Context, now time.Time) (int64, error) {
+	cutoff := now.Add(-e.conf.Window).Unix()
+	return pruneScript.Run(ctx, e.redisCache,
+		[]string{exploreScoresKey, exploreCreatedKey},
+		cutoff,
+	).Int64()
+}
+
+func (e ExploreRepository) Rebase(ctx context.Context, now time.Time) (bool, error) {
+	rebased, err := rebaseScript.Run(ctx, e.redisCache,
+		[]string{exploreScoresKey, exploreCreatedKey, exploreEpochKey},
+		now.Unix(), int64(e.conf.RebaseAfter.Seconds()), e.conf.HalfLife.Seconds(),
+	).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	if rebased == 1 {
+		e.logger.Info("Rebased explore scores", zap.Int64("epoch", now.Unix()))
+	}
+	return rebased == 1, nil
+}
+
+func (e ExploreRepository) run(ctx context.Context, script *redis.Script, postId string, weight float64, at time.Time) error {
+	err := script.Run(ctx, e.redisCache,
+		[]string{exploreScoresKey, exploreCreatedKey, exploreEpochKey},
+		postId, strconv.FormatFloat(weight, 'f', -1, 64), at.Unix(), e.conf.HalfLife.Seconds(),
+	).Err()
+	if err != nil && err != redis.Nil {
+		e.logger.Error("Failed to update explore score",
+			zap.String("post_id", postId),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// FeedSnapshotRepository stores the ranked order of a viewer's feed so later
+// pages read the same order even while scores keep moving.
+type FeedSnapshotRepository struct {
+	redisCache *redis.Client
+	ttl        time.Duration
+	logger     util.Logger
+}
+
+func NewFeedSnapshotRepository(redisClient *redis.Client, ttl time.Duration, logger util.Logger) posts.FeedSnapshotRepository {
+	return FeedSnapshotRepository{
+		redisCache: redisClient,
+		ttl:        ttl,
+		logger:     logger,
+	}
+}
+
+func (f FeedSnapshotRepository) SaveSnapshot(ctx context.Context, userId string, postIds []string) (string, error) {
+	token := uuid.NewString()
+	key := feedSnapshotKey(userId, token)
+
+	values := make([]interface{}, len(postIds))
+	for i, id := range postIds {
+		values[i] = id
+	}
+
+	pipe := f.redisCache.TxPipeline()
+	pipe.RPush(ctx, key, values...)
+	pipe.Expire(ctx, key, f.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		f.logger.Error("Failed to store feed snapshot",
+			zap.String("user_id", userId),
+			zap.Error(err),
+		)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (f FeedSnapshotRepository) FindSnapshotPage(ctx context.Context, userId string, token string, offset int, limit int) ([]string, bool, error) {
+	key := feedSnapshotKey(userId, token)
+
+	// One extra id tells whether another page follows.
+	pipe := f.redisCache.Pipeline()
+	exists := pipe.Exists(ctx, key)
+	page := pipe.LRange(ctx, key, int64(offset), int64(offset+limit))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, false, err
+	}
+	if exists.Val() == 0 {
+		return nil, false, fmt.Errorf("%w: feed cursor expired", posts.ErrInvalidCursor)
+	}
+
+	ids := page.Val()
+	if len(ids) > limit {
+		return ids[:limit], true, nil
+	}
+	return ids, false, nil
+}
+
+func feedSnapshotKey(userId string, token string) string {
+	return fmt.Sprintf("feed:ranked:%s:%s", userId, token)
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/posts/entities"
+
+	"gorm.io/gorm"
+)
+
+// MigratePostImageKeys moves posts from storing image file paths in
+// image_urls to storing blob keys in image_keys. Paths were written as
+// "storage/<key>", so stripping that prefix yields the key under the local
+// store's default root. It must run before AutoMigrate adds image_keys.
+func MigratePostImageKeys(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&entities.Post{}) ||
+		!migrator.HasColumn(&entities.Post{}, "image_urls") ||
+		migrator.HasColumn(&entities.Post{}, "image_keys") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().RenameColumn(&entities.Post{}, "image_urls", "image_keys"); err != nil {
+			return err
+		}
+		return tx.Exec(`
+			UPDATE posts
+			SET image_keys = ARRAY(
+				SELECT regexp_replace(k, '^(\./)?storage/', '') FROM unnest(image_keys) AS k
+			)
+			WHERE image_keys IS NOT NULL`,
+		).Error
+	})
+}
@@ -1,12 +1,15 @@
 package repositories
 
 import (
+	"bootcamp-content-interaction-service/domains/outbox"
 	"bootcamp-content-interaction-service/domains/posts"
 	"bootcamp-content-interaction-service/domains/posts/entities"
 	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/events"
 	"bootcamp-content-interaction-service/shared/util"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,38 +18,68 @@ import (
 	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostRepository struct {
 	db infrastructures.Database
 	redisCache *redis.Client
     logger util.Logger
+	outbox outbox.OutboxRepository
 }
 
-func NewPostRepository(db infrastructures.Database, redisClient *redis.Client, logger util.Logger) posts.PostRepository {
+func NewPostRepository(db infrastructures.Database, redisClient *redis.Client, logger util.Logger, outboxRepo outbox.OutboxRepository) posts.PostRepository {
 	return PostRepository{
 		db: db,
 		redisCache: redisClient,
         logger: logger,
+		outbox: outboxRepo,
 	}
 }
 
-func (p PostRepository) UpdatePost(ctx context.Context, post *entities.Post) (*entities.Post, error) {
-    result := p.db.GetInstance().WithContext(ctx).Save(post)
-    if result.Error != nil {
-        return nil, result.Error
+// MutatePost applies mutate to the post while its row is locked, then saves
+// it under the next revision together with a POST_UPDATED event. The cached
+// copies are dropped straight after the commit instead of waiting for the
+// event, so the next read already sees the new revision.
+func (p PostRepository) MutatePost(ctx context.Context, id string, mutate func(post *entities.Post) error) (*entities.Post, error) {
+    parsedID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, posts.ErrPostNotFound
     }
 
-    postJSON, err := json.Marshal(post)
-    if err == nil {
-        key := "post:" + post.ID.String()
-        _ = p.redisCache.Set(ctx, key, postJSON, time.Hour).Err()
-		p.logger.Info("Update post in redis",
-            zap.String("post_id", post.ID.String()),
-        )
+    var post entities.Post
+    err = p.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", parsedID).First(&post).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return posts.ErrPostNotFound
+        }
+        if err != nil {
+            return err
+        }
+
+        if err := mutate(&post); err != nil {
+            return err
+        }
+        post.Revision++
+        post.UpdatedAt = time.Now()
+
+        if err := tx.Save(&post).Error; err != nil {
+            return err
+        }
+
+        return p.outbox.Append(tx, events.POST_UPDATED, post.ID, events.PostPayload{
+            PostID:  post.ID,
+            UserID:  post.UserID,
+            Caption: post.Caption,
+        })
+    })
+    if err != nil {
+        return nil, err
     }
 
-    return post, nil
+    _ = p.InvalidateCache(ctx, post.ID.String(), post.UserID.String())
+    return &post, nil
 }
 
 func (p PostRepository) DeletePost(ctx context.Context, id string) error {
@@ -55,22 +88,28 @@ func (p PostRepository) DeletePost(ctx context.Context, id string) error {
         return fmt.Errorf("invalid UUID format: %w", err)
     }
 
-    result := p.db.GetInstance().WithContext(ctx).Where("id = ?", parsedID).Delete(&entities.Post{})
-    if result.Error != nil {
-        return result.Error
-    }
-    if result.RowsAffected == 0 {
-        return fmt.Errorf("no post found with ID: %s", id)
-    }
+    return p.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        var post entities.Post
+        err := tx.Where("id = ?", parsedID).First(&post).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return fmt.Errorf("no post found with ID: %s", id)
+        }
+        if err != nil {
+            return err
+        }
 
-    key := "post:" + parsedID.String()
-    _ = p.redisCache.Del(ctx, key).Err()
-	p.logger.Info("Delete post from redis",
-        zap.String("post_id", parsedID.String()),
-    )
+        if err := tx.Delete(&post).Error; err != nil {
+            return err
+        }
 
-    return nil
+        return p.outbox.Append(tx, events.POST_DELETED, post.ID, events.PostPayload{
+            PostID:  post.ID,
+            UserID:  post.UserID,
+            Caption: post.Caption,
+        })
+    })
 }
+
 func (p PostRepository) FindById(ctx context.Context, id string) (*entities.Post, error) {
 	var post entities.Post
     key := "post:" + id
@@ -140,85 +179,56 @@ func (p PostRepository) FindAllByUserId(ctx context.Context, userId string) ([]*
     return posts, nil
 }
 
-func (p PostRepository) FindAll(ctx context.Context) ([]*entities.Post, error) {
-    key := "feed_posts"
-    var posts []*entities.Post
-
-    cached, err := p.redisCache.LRange(ctx, key, 0, -1).Result()
-    if err == nil && len(cached) > 0 {
-		p.logger.Info("Cache hit - returning posts from redis", 
-            zap.String("cache_key", key),
-        )
-        for _, jsonItem := range cached {
-            var post entities.Post
-            if err := json.Unmarshal([]byte(jsonItem), &post); err == nil {
-                posts = append(posts, &post)
-            }
-        }
-        return posts, nil
-    }
-
-    result := p.db.GetInstance().WithContext(ctx).Order("created_at DESC").Find(&posts)
-    p.logger.Info("Get all data from DB")
-	if result.Error != nil {
-        return nil, result.Error
-    }
-
-    for _, post := range posts {
-        postJSON, _ := json.Marshal(post)
-        _ = p.redisCache.LPush(ctx, key, postJSON).Err()
-		p.logger.Info("Set post in cache",
-            zap.String("post_json", string(postJSON)),
-        )
-    }
-    _ = p.redisCache.LTrim(ctx, key, 0, 99)          
-    _ = p.redisCache.Expire(ctx, key, time.Hour)
-
-    return posts, nil
-}
-
 func (p PostRepository) SavePost(ctx context.Context, post *entities.Post) (*entities.Post, error) {
     
 
 	postModel := &entities.Post{
         ID:        uuid.New(),
         UserID:    post.UserID,
-        ImageURLs: pq.StringArray(post.ImageURLs),
+        ImageKeys: pq.StringArray(post.ImageKeys),
+        ImageRenditions: post.ImageRenditions,
+        ImageIDs: pq.StringArray(post.ImageIDs),
+        ImageAltTexts: pq.StringArray(post.ImageAltTexts),
+        MediaInfo: post.MediaInfo,
+        Revision: 1,
         Caption:   post.Caption,
         Tags:      pq.StringArray(post.Tags),
         CreatedAt: time.Now(),
     }
 
-    result := p.db.GetInstance().WithContext(ctx).Create(postModel)
-    if result.Error != nil {
-        return nil, result.Error
-    }
+    err := p.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Create(postModel).Error; err != nil {
+            return err
+        }
 
-    postJSON, err := json.Marshal(postModel)
+        return p.outbox.Append(tx, events.POST_CREATED, postModel.ID, events.PostPayload{
+            PostID:  postModel.ID,
+            UserID:  postModel.UserID,
+            Caption: postModel.Caption,
+        })
+    })
     if err != nil {
-        fmt.Printf("Redis marshal failed: %v\n", err)
-        return postModel, nil
+        return nil, err
     }
 
-    _ = p.redisCache.Set(ctx, "post:"+postModel.ID.String(), postJSON, time.Hour).Err()
-    p.logger.Info("Set in cache",
-        zap.String("post_key", postModel.ID.String()),
-    )
+    return postModel, nil
+}
 
-    userFeedKey := "user_posts:" + postModel.UserID.String()
-    _ = p.redisCache.LPush(ctx, userFeedKey, postJSON)
-    _ = p.redisCache.Expire(ctx, userFeedKey, time.Hour)
-	p.logger.Info("Set in cache",
-        zap.String("user_posts_key", postModel.ID.String()),
-    )
+func (p PostRepository) InvalidateCache(ctx context.Context, postId string, userId string) error {
+    keys := []string{"post:" + postId, "user_posts:" + userId}
 
-    feedKey := "feed_posts"
-    _ = p.redisCache.LPush(ctx, feedKey, postJSON)
-    _ = p.redisCache.LTrim(ctx, feedKey, 0, 99)
-    _ = p.redisCache.Expire(ctx, feedKey, time.Hour)
-	p.logger.Info("Set in cache cache with key feed_posts")
+    if err := p.redisCache.Del(ctx, keys...).Err(); err != nil {
+        p.logger.Warn("Failed to invalidate post cache",
+            zap.String("post_id", postId),
+            zap.Error(err),
+        )
+        return err
+    }
 
-    return postModel, nil
+    p.logger.Info("Invalidated post cache",
+        zap.Strings("keys", keys),
+    )
+    return nil
 }
 
 func (p PostRepository) FindByUserIDs(ctx context.Context, userIds []string, limit, offset int) ([]*entities.Post, error) {
@@ -297,3 +307,180 @@ func (p PostRepository) FindByUserIDs(ctx context.Context, userIds []string, lim
 	return posts, nil
 }
 
+// FindRecent returns the newest posts from everyone but excludeUserIds. It
+// backs the personal feed while the user graph service is unavailable.
+func (p PostRepository) FindRecent(ctx context.Context, excludeUserIds []string, limit, offset int) ([]*entities.Post, error) {
+	var posts []*entities.Post
+
+	query := p.db.GetInstance().WithContext(ctx)
+	if len(excludeUserIds) > 0 {
+		query = query.Where("user_id NOT IN ?", excludeUserIds)
+	}
+
+	result := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&posts)
+
+	if result.Error != nil {
+		p.logger.Error("Database query failed",
+			zap.Error(result.Error),
+		)
+		return nil, result.Error
+	}
+
+	return posts, nil
+}
+
+func (p PostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	var posts []*entities.Post
+	if len(ids) == 0 {
+		return posts, nil
+	}
+
+	result := p.db.GetInstance().WithContext(ctx).Where("id IN ?", ids).Find(&posts)
+	if result.Error != nil {
+		p.logger.Error("Database query failed",
+			zap.Error(result.Error),
+		)
+		return nil, result.Error
+	}
+
+	return posts, nil
+}
+
+// engagementQuery selects posts with their current like, comment and save
+// totals; callers append the WHERE clause.
+const engagementQuery = `
+	SELECT p.id, p.user_id, p.created_at,
+		(SELECT COUNT(*) FROM likes l WHERE l.post_id = p.id AND l.deleted_at IS NULL) AS likes,
+		(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id) AS comments,
+		(SELECT COUNT(*) FROM post_saves s WHERE s.post_id = p.id) AS saves
+	FROM posts p`
+
+// FindEngagementSince returns posts created after since with their current
+// like, comment and save totals.
+func (p PostRepository) FindEngagementSince(ctx context.Context, since time.Time) ([]*entities.PostEngagement, error) {
+	var rows []*entities.PostEngagement
+	result := p.db.GetInstance().WithContext(ctx).Raw(engagementQuery+`
+		WHERE p.created_at >= ?`,
+		since,
+	).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return rows, nil
+}
+
+// FindFeedCandidates returns the newest posts by userIds created after since,
+// with their engagement totals, for ranking the personal feed.
+func (p PostRepository) FindFeedCandidates(ctx context.Context, userIds []string, since time.Time, limit int) ([]*entities.PostEngagement, error) {
+	var rows []*entities.PostEngagement
+	if len(userIds) == 0 {
+		return rows, nil
+	}
+
+	result := p.db.GetInstance().WithContext(ctx).Raw(engagementQuery+`
+		WHERE p.user_id IN ? AND p.created_at >= ?
+		ORDER BY p.created_at DESC
+		LIMIT ?`,
+		userIds, since, limit,
+	).Scan(&rows)
+	if result.Error != nil {
+		p.logger.Error("Failed to load feed candidates", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return rows, nil
+}
+
+// FindAffinity counts the likes and comments viewerId left since since on
+// posts by each of authorIds. Authors without interactions are left out.
+func (p PostRepository) FindAffinity(ctx context.Context, viewerId string, authorIds []string, since time.Time) (map[string]int64, error) {
+	affinity := make(map[string]int64)
+	if len(authorIds) == 0 {
+		return affinity, nil
+	}
+
+	var rows []struct {
+		AuthorID     uuid.UUID
+		Interactions int64
+	}
+	result := p.db.GetInstance().WithContext(ctx).Raw(`
+		SELECT p.user_id AS author_id, COUNT(*) AS interactions
+		FROM (
+			SELECT post_id FROM likes WHERE user_id = ? AND deleted_at IS NULL AND created_at >= ?
+			UNION ALL
+			SELECT post_id FROM comments WHERE user_id = ? AND created_at >= ?
+		) i
+		JOIN posts p ON p.id = i.post_id
+		WHERE p.user_id IN ?
+		GROUP BY p.user_id`,
+		viewerId, since, viewerId, since, authorIds,
+	).Scan(&rows)
+	if result.Error != nil {
+		p.logger.Error("Failed to load feed affinity", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		affinity[row.AuthorID.String()] = row.Interactions
+	}
+	return affinity, nil
+}
+
+// SearchPosts returns a page of the posts whose caption matches search, best
+// first, and the cursor of the page after it, nil on the last page.
+func (p PostRepository) SearchPosts(ctx context.Context, search util.TextSearch) ([]*entities.Post, *util.ScoreCursor, error) {
+	found := []*entities.Post{}
+	tsquery, query, ok := search.TSQuery()
+	if !ok {
+		return found, nil, nil
+	}
+	score, decay := search.ScoreSQL("p.search_vector", "p.created_at")
+
+	sql := `
+		SELECT * FROM (
+			SELECT p.*, ` + score + ` AS score
+			FROM posts p
+			CROSS JOIN ` + tsquery + ` search_query
+			WHERE p.search_vector @@ search_query`
+	args := []interface{}{decay, query}
+	if len(search.ExcludeUserIDs) > 0 {
+		sql += ` AND p.user_id NOT IN ?`
+		args = append(args, search.ExcludeUserIDs)
+	}
+	sql += `
+		) matches`
+	if search.Cursor != nil {
+		sql += `
+		WHERE (score, id) < (?, ?)`
+		args = append(args, search.Cursor.Score, search.Cursor.ID)
+	}
+	sql += `
+		ORDER BY score DESC, id DESC
+		LIMIT ?`
+	args = append(args, search.Limit+1)
+
+	var rows []struct {
+		entities.Post `gorm:"embedded"`
+		Score         float64
+	}
+	if err := p.db.GetInstance().WithContext(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		p.logger.Error("Failed to search posts", zap.Error(err))
+		return nil, nil, err
+	}
+
+	var next *util.ScoreCursor
+	if len(rows) > search.Limit {
+		rows = rows[:search.Limit]
+		last := rows[len(rows)-1]
+		next = &util.ScoreCursor{Score: last.Score, ID: last.ID}
+	}
+	for i := range rows {
+		found = append(found, &rows[i].Post)
+	}
+	return found, next, nil
+}
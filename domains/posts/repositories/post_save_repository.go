@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/outbox"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/events"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostSaveRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+	outbox outbox.OutboxRepository
+}
+
+func NewPostSaveRepository(db infrastructures.Database, logger util.Logger, outboxRepo outbox.OutboxRepository) posts.PostSaveRepository {
+	return PostSaveRepository{
+		db:     db,
+		logger: logger,
+		outbox: outboxRepo,
+	}
+}
+
+func (p PostSaveRepository) AddSave(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	return p.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post entities.Post
+		err := tx.Select("id", "user_id").Where("id = ?", postId).First(&post).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return posts.ErrPostNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		result := tx.Omit("User", "Post").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entities.PostSave{
+				ID:        uuid.New(),
+				UserID:    userId,
+				PostID:    postId,
+				CreatedAt: time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		// Saving twice is a no-op and must not count twice towards explore.
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return p.outbox.Append(tx, events.POST_SAVED, postId, events.SavePayload{
+			PostID:      postId,
+			UserID:      userId,
+			PostOwnerID: post.UserID,
+		})
+	})
+}
+
+func (p PostSaveRepository) RemoveSave(ctx context.Context, userId uuid.UUID, postId uuid.UUID) error {
+	return p.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var removed []entities.PostSave
+		result := tx.Clauses(clause.Returning{}).
+			Where("user_id = ? AND post_id = ?", userId, postId).
+			Delete(&removed)
+		if result.Error != nil {
+			return result.Error
+		}
+		if len(removed) == 0 {
+			return posts.ErrSaveNotFound
+		}
+
+		var post entities.Post
+		if err := tx.Select("user_id").Where("id = ?", postId).First(&post).Error; err != nil {
+			return err
+		}
+
+		return p.outbox.Append(tx, events.POST_UNSAVED, postId, events.SavePayload{
+			PostID:      postId,
+			UserID:      userId,
+			PostOwnerID: post.UserID,
+			EngagedAt:   &removed[0].CreatedAt,
+		})
+	})
+}
+
+func (p PostSaveRepository) FindSavedPosts(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entities.Post, error) {
+	var saved []*entities.Post
+	result := p.db.GetInstance().WithContext(ctx).
+		Joins("JOIN post_saves ON post_saves.post_id = posts.id").
+		Where("post_saves.user_id = ?", userId).
+		Order("post_saves.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&saved)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// SeenRepository keeps the posts each user has seen in a sorted set scored
+// by impression time. The set is trimmed to the newest SeenCap ids and
+// expires SeenTTL after the last impression, so it stays bounded.
+type SeenRepository struct {
+	redisCache *redis.Client
+	conf       config.Feed
+	logger     util.Logger
+}
+
+func NewSeenRepository(redisClient *redis.Client, conf config.Feed, logger util.Logger) posts.SeenRepository {
+	return SeenRepository{
+		redisCache: redisClient,
+		conf:       conf.WithDefaults(),
+		logger:     logger,
+	}
+}
+
+func (s SeenRepository) MarkSeen(ctx context.Context, userId string, postIds []string) error {
+	key := seenKey(userId)
+	score := float64(time.Now().Unix())
+
+	members := make([]redis.Z, len(postIds))
+	for i, id := range postIds {
+		members[i] = redis.Z{Score: score, Member: id}
+	}
+
+	pipe := s.redisCache.TxPipeline()
+	pipe.ZAdd(ctx, key, members...)
+	pipe.ZRemRangeByRank(ctx, key, 0, int64(-s.conf.SeenCap-1))
+	pipe.Expire(ctx, key, s.conf.SeenTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("Failed to record impressions",
+			zap.String("user_id", userId),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (s SeenRepository) FindSeen(ctx context.Context, userId string, postIds []string) (map[string]bool, error) {
+	seen := make(map[string]bool)
+	if len(postIds) == 0 {
+		return seen, nil
+	}
+
+	scores, err := s.redisCache.ZMScore(ctx, seenKey(userId), postIds...).Result()
+	if err == redis.Nil {
+		return seen, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing members come back as 0; impression scores are timestamps.
+	for i, score := range scores {
+		if score > 0 {
+			seen[postIds[i]] = true
+		}
+	}
+	return seen, nil
+}
+
+func seenKey(userId string) string {
+	return fmt.Sprintf("seen:%s", userId)
+}
@@ -0,0 +1,195 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/entities"
+	"bootcamp-content-interaction-service/domains/posts/handlers/http"
+	"bootcamp-content-interaction-service/domains/posts/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ViewRankedFeed returns the personal feed ordered by score instead of by
+// time. The first page ranks recent posts from followed accounts, moves posts
+// the viewer has already seen behind the unseen ones, and stores the order as
+// a snapshot; the returned cursor walks that snapshot, so pages neither
+// repeat nor skip posts while scores change. When the user graph service is
+// unavailable the first page degrades to the newest posts from everyone,
+// without a cursor, and later pages go without the caught-up marker.
+func (p PostUseCase) ViewRankedFeed(ctx context.Context, userId string, cursor string, limit int) (*responses.FeedPage, error) {
+	hidden, err := p.relationChecker.HiddenUserIDs(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var token string
+	var offset int
+	if cursor != "" {
+		token, offset, err = util.DecodeSnapshotCursor(cursor)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", posts.ErrInvalidCursor, err.Error())
+		}
+	}
+
+	followingIDs, err := p.userGraphService.GetFollowings(ctx, userId)
+	graphDown := errors.Is(err, http.ErrUserGraphUnavailable)
+	if err != nil && !graphDown {
+		return nil, err
+	}
+	authorIDs := without(followingIDs, hidden)
+
+	if cursor == "" {
+		if graphDown {
+			recent, err := p.postRepository.FindRecent(ctx, hidden, limit, 0)
+			if err != nil {
+				return nil, err
+			}
+			return &responses.FeedPage{Posts: p.toPostResponses(recent), Degraded: true}, nil
+		}
+
+		ranked, err := p.rankFeed(ctx, userId, authorIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(ranked) == 0 {
+			return &responses.FeedPage{Posts: []*responses.PostResponse{}, CaughtUp: true}, nil
+		}
+
+		token, err = p.snapshotRepository.SaveSnapshot(ctx, userId, ranked)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pageIDs, more, err := p.snapshotRepository.FindSnapshotPage(ctx, userId, token, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := p.postRepository.FindByIDs(ctx, pageIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entities.Post, len(found))
+	for _, post := range found {
+		byID[post.ID.String()] = post
+	}
+
+	// Posts deleted, or authors blocked, since the snapshot was taken are
+	// dropped from the page rather than backfilled.
+	excluded := make(map[string]bool, len(hidden))
+	for _, id := range hidden {
+		excluded[id] = true
+	}
+	page := &responses.FeedPage{Posts: []*responses.PostResponse{}}
+	for _, id := range pageIDs {
+		post, ok := byID[id]
+		if !ok || excluded[post.UserID.String()] {
+			continue
+		}
+		page.Posts = append(page.Posts, p.toPostResponse(post))
+	}
+
+	if more {
+		page.NextCursor = util.EncodeSnapshotCursor(token, offset+len(pageIDs))
+	}
+	if !graphDown {
+		page.CaughtUp, err = p.caughtUp(ctx, userId, authorIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// rankFeed scores the recent posts of authorIds for viewerId and returns
+// their ids best first.
+func (p PostUseCase) rankFeed(ctx context.Context, viewerId string, authorIds []string) ([]string, error) {
+	now := time.Now()
+
+	candidates, err := p.postRepository.FindFeedCandidates(ctx, authorIds, now.Add(-p.feedConf.Window), p.feedConf.Candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make([]string, 0, len(candidates))
+	counted := make(map[string]bool)
+	for _, candidate := range candidates {
+		id := candidate.UserID.String()
+		if !counted[id] {
+			counted[id] = true
+			authors = append(authors, id)
+		}
+	}
+
+	affinity, err := p.postRepository.FindAffinity(ctx, viewerId, authors, now.Add(-p.feedConf.AffinityWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := rankCandidates(candidates, affinity, p.feedConf, now)
+
+	seen, err := p.seenRepository.FindSeen(ctx, viewerId, ranked)
+	if err != nil {
+		return nil, err
+	}
+	return deprioritise(ranked, seen), nil
+}
+
+// deprioritise moves seen ids behind unseen ones, keeping the ranked order
+// within each group.
+func deprioritise(ranked []string, seen map[string]bool) []string {
+	ordered := make([]string, 0, len(ranked))
+	for _, id := range ranked {
+		if !seen[id] {
+			ordered = append(ordered, id)
+		}
+	}
+	for _, id := range ranked {
+		if seen[id] {
+			ordered = append(ordered, id)
+		}
+	}
+	return ordered
+}
+
+// rankCandidates orders candidates by score, breaking ties by newest first
+// and then by id so the order is deterministic.
+func rankCandidates(candidates []*entities.PostEngagement, affinity map[string]int64, conf config.Feed, now time.Time) []string {
+	scores := make(map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		engagement := candidate.Likes + candidate.Comments + candidate.Saves
+		age := now.Sub(candidate.CreatedAt)
+		if age < 0 {
+			age = 0
+		}
+
+		scores[candidate.ID.String()] = conf.AffinityWeight*math.Log1p(float64(affinity[candidate.UserID.String()])) +
+			conf.EngagementWeight*math.Log1p(float64(engagement)) +
+			conf.RecencyWeight*math.Exp2(-age.Hours()/conf.HalfLife.Hours())
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		scoreA, scoreB := scores[a.ID.String()], scores[b.ID.String()]
+		if scoreA != scoreB {
+			return scoreA > scoreB
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID.String() > b.ID.String()
+	})
+
+	ranked := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		ranked[i] = candidate.ID.String()
+	}
+	return ranked
+}
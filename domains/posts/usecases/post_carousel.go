@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/entities"
+	"bootcamp-content-interaction-service/domains/posts/models/requests"
+	"bootcamp-content-interaction-service/domains/posts/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AddImages appends completed uploads to the end of the carousel.
+func (p PostUseCase) AddImages(ctx context.Context, postId string, request *requests.AddImagesRequest) (*responses.PostResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed []postImage
+	updated, err := p.postRepository.MutatePost(ctx, postId, func(post *entities.Post) error {
+		if err := checkEditable(post, user.UserId, request.Revision); err != nil {
+			return err
+		}
+
+		images, err := p.claimImages(ctx, user.UserId, request.MediaIDs, request.AltTexts)
+		if err != nil {
+			return err
+		}
+		claimed = images
+		return setPostImages(post, append(postImages(post), claimed...))
+	})
+	if err != nil {
+		p.releaseImages(ctx, claimed)
+		return nil, err
+	}
+
+	return p.toPostResponse(updated), nil
+}
+
+// ReorderImages puts the carousel in the order of request.MediaIDs, which
+// must list every image of the post exactly once.
+func (p PostUseCase) ReorderImages(ctx context.Context, postId string, request *requests.ReorderImagesRequest) (*responses.PostResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := p.postRepository.MutatePost(ctx, postId, func(post *entities.Post) error {
+		if err := checkEditable(post, user.UserId, request.Revision); err != nil {
+			return err
+		}
+
+		current := postImages(post)
+		if len(request.MediaIDs) != len(current) {
+			return fmt.Errorf("%w: order must list all %d images", posts.ErrInvalidPost, len(current))
+		}
+		byID := make(map[string]postImage, len(current))
+		for _, image := range current {
+			byID[image.ID] = image
+		}
+
+		ordered := make([]postImage, 0, len(current))
+		for _, id := range request.MediaIDs {
+			image, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("%w: %s", posts.ErrImageNotFound, id)
+			}
+			ordered = append(ordered, image)
+		}
+		return setPostImages(post, ordered)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.toPostResponse(updated), nil
+}
+
+// UpdateImage changes the alt text of one image.
+func (p PostUseCase) UpdateImage(ctx context.Context, postId string, mediaId string, request *requests.UpdateImageRequest) (*responses.PostResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := p.postRepository.MutatePost(ctx, postId, func(post *entities.Post) error {
+		if err := checkEditable(post, user.UserId, request.Revision); err != nil {
+			return err
+		}
+
+		images := postImages(post)
+		i := indexOfImage(images, mediaId)
+		if i < 0 {
+			return fmt.Errorf("%w: %s", posts.ErrImageNotFound, mediaId)
+		}
+		images[i].AltText = strings.TrimSpace(request.AltText)
+		return setPostImages(post, images)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.toPostResponse(updated), nil
+}
+
+// RemoveImage takes one image out of the carousel. A post keeps at least one
+// image, so the last one cannot be removed.
+func (p PostUseCase) RemoveImage(ctx context.Context, postId string, mediaId string, revision int) (*responses.PostResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []postImage
+	updated, err := p.postRepository.MutatePost(ctx, postId, func(post *entities.Post) error {
+		if err := checkEditable(post, user.UserId, revision); err != nil {
+			return err
+		}
+
+		images := postImages(post)
+		i := indexOfImage(images, mediaId)
+		if i < 0 {
+			return fmt.Errorf("%w: %s", posts.ErrImageNotFound, mediaId)
+		}
+		if len(images) == 1 {
+			return fmt.Errorf("%w: a post needs at least one image", posts.ErrInvalidPost)
+		}
+
+		removed = []postImage{images[i]}
+		return setPostImages(post, append(images[:i], images[i+1:]...))
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.releaseImages(ctx, removed)
+
+	return p.toPostResponse(updated), nil
+}
+
+// checkEditable rejects changes from anyone but the author, and changes
+// made against a revision other than the current one. A zero revision
+// skips the check.
+func checkEditable(post *entities.Post, userId string, revision int) error {
+	if post.UserID.String() != userId {
+		return posts.ErrForbidden
+	}
+	if revision != 0 && revision != post.Revision {
+		return fmt.Errorf("%w: current revision is %d", posts.ErrPostConflict, post.Revision)
+	}
+	return nil
+}
+
+func indexOfImage(images []postImage, id string) int {
+	for i, image := range images {
+		if image.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/entities"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// postImage is one item of a post's carousel, an image or a video,
+// gathered from the parallel image columns of the post.
+type postImage struct {
+	ID         string
+	Type       string
+	Renditions map[string]string
+	AltText    string
+	Width      int
+	Height     int
+	DurationMs int64
+}
+
+// mediaInfo is the entry of Post.MediaInfo for one item.
+type mediaInfo struct {
+	Type       string `json:"type"`
+	Width      int    `json:"width,omitempty"`
+	Height     int    `json:"height,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+}
+
+// claimImages claims the completed uploads mediaIds of userId, in order,
+// with the alt text at the same position of altTexts. Items left without
+// one are offered to the alt text generator. The caller owns the images
+// from then on and releases them if it cannot keep them.
+func (p PostUseCase) claimImages(ctx context.Context, userId string, mediaIds []string, altTexts []string) ([]postImage, error) {
+	if err := checkAltTexts(mediaIds, altTexts); err != nil {
+		return nil, err
+	}
+
+	claimed, err := p.uploadClaimer.ClaimUploads(ctx, userId, mediaIds)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]postImage, len(claimed))
+	for i, stored := range claimed {
+		images[i] = postImage{
+			ID:         mediaIds[i],
+			Type:       stored.Type,
+			Renditions: stored.Renditions,
+			Width:      stored.Width,
+			Height:     stored.Height,
+			DurationMs: stored.DurationMs,
+		}
+		if i < len(altTexts) {
+			images[i].AltText = strings.TrimSpace(altTexts[i])
+		}
+		if images[i].AltText == "" {
+			images[i].AltText = p.generateAltText(ctx, images[i])
+		}
+	}
+	return images, nil
+}
+
+// generateAltText asks the alt text generator to describe image. A failing
+// generator leaves the image without alt text rather than failing the post.
+func (p PostUseCase) generateAltText(ctx context.Context, image postImage) string {
+	altText, err := p.altTextGenerator.GenerateAltText(ctx, image.Type, image.Renditions)
+	if err != nil {
+		p.logger.Warn("Failed to generate alt text", zap.String("media_id", image.ID), zap.Error(err))
+		return ""
+	}
+	return strings.TrimSpace(altText)
+}
+
+// checkAltTexts rejects alt texts that have no media to describe.
+func checkAltTexts(mediaIds []string, altTexts []string) error {
+	if len(altTexts) > len(mediaIds) {
+		return fmt.Errorf("%w: %d alt texts for %d media", posts.ErrInvalidPost, len(altTexts), len(mediaIds))
+	}
+	return nil
+}
+
+// altTextComplete reports whether every item of post has alt text.
+func altTextComplete(images []postImage) bool {
+	for _, image := range images {
+		if strings.TrimSpace(image.AltText) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// releaseImages releases images. The same image attached twice holds two
+// references, so each one is released.
+func (p PostUseCase) releaseImages(ctx context.Context, images []postImage) {
+	if len(images) == 0 {
+		return
+	}
+
+	renditions := make([]map[string]string, len(images))
+	for i, image := range images {
+		renditions[i] = image.Renditions
+	}
+	p.imagePipeline.ReleaseImages(ctx, renditions)
+}
+
+// deleteImages releases every image post points at.
+func (p PostUseCase) deleteImages(ctx context.Context, post *entities.Post) {
+	p.releaseImages(ctx, postImages(post))
+}
+
+// postImages lists the carousel of post in order. Images attached before
+// they had IDs get one derived from the post and their position, which
+// stays the same until the carousel is next saved.
+func postImages(post *entities.Post) []postImage {
+	renditions := imageRenditions(post)
+	var infos []mediaInfo
+	if post.MediaInfo != "" {
+		_ = json.Unmarshal([]byte(post.MediaInfo), &infos)
+	}
+
+	images := make([]postImage, len(renditions))
+	for i, rendition := range renditions {
+		images[i].Renditions = rendition
+		images[i].Type = util.MEDIA_IMAGE
+		if i < len(infos) {
+			if infos[i].Type != "" {
+				images[i].Type = infos[i].Type
+			}
+			images[i].Width = infos[i].Width
+			images[i].Height = infos[i].Height
+			images[i].DurationMs = infos[i].DurationMs
+		}
+		if i < len(post.ImageIDs) && post.ImageIDs[i] != "" {
+			images[i].ID = post.ImageIDs[i]
+		} else {
+			images[i].ID = uuid.NewSHA1(post.ID, []byte(strconv.Itoa(i)+":"+post.ImageKeys[i])).String()
+		}
+		if i < len(post.ImageAltTexts) {
+			images[i].AltText = post.ImageAltTexts[i]
+		}
+	}
+	return images
+}
+
+// setPostImages stores images as the carousel of post. ImageKeys gets the
+// full rendition of every image, the video itself for a video, and
+// ImageRenditions all of them.
+func setPostImages(post *entities.Post, images []postImage) error {
+	keys := make([]string, len(images))
+	ids := make([]string, len(images))
+	altTexts := make([]string, len(images))
+	renditions := make([]map[string]string, len(images))
+	infos := make([]mediaInfo, len(images))
+	for i, image := range images {
+		keys[i] = image.Renditions[util.RENDITION_FULL]
+		if image.Type == util.MEDIA_VIDEO {
+			keys[i] = image.Renditions[util.RENDITION_VIDEO]
+		}
+		ids[i] = image.ID
+		altTexts[i] = image.AltText
+		renditions[i] = image.Renditions
+		infos[i] = mediaInfo{Type: image.Type, Width: image.Width, Height: image.Height, DurationMs: image.DurationMs}
+		if infos[i].Type == "" {
+			infos[i].Type = util.MEDIA_IMAGE
+		}
+	}
+
+	encoded, err := json.Marshal(renditions)
+	if err != nil {
+		return err
+	}
+	encodedInfo, err := json.Marshal(infos)
+	if err != nil {
+		return err
+	}
+	post.ImageKeys = keys
+	post.ImageIDs = ids
+	post.ImageAltTexts = altTexts
+	post.ImageRenditions = string(encoded)
+	post.MediaInfo = string(encodedInfo)
+	return nil
+}
+
+// imageRenditions decodes post.ImageRenditions, one map per image key.
+// Images stored before renditions existed only have their original, which
+// is reported as the full rendition.
+func imageRenditions(post *entities.Post) []map[string]string {
+	var renditions []map[string]string
+	if post.ImageRenditions != "" {
+		_ = json.Unmarshal([]byte(post.ImageRenditions), &renditions)
+	}
+
+	for i, key := range post.ImageKeys {
+		if i >= len(renditions) {
+			renditions = append(renditions, nil)
+		}
+		if len(renditions[i]) == 0 {
+			renditions[i] = map[string]string{util.RENDITION_FULL: key}
+		}
+	}
+	return renditions[:len(post.ImageKeys)]
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/models/requests"
+	"bootcamp-content-interaction-service/domains/posts/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+)
+
+// SearchPosts finds posts by their caption, ranked by relevance and recency.
+// Posts by users hidden from the viewer are left out; anonymous viewers see
+// every match.
+func (p PostUseCase) SearchPosts(ctx context.Context, request *requests.SearchRequest) (*responses.PostSearchPage, error) {
+	cursor, err := util.DecodeScoreCursor(request.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", posts.ErrInvalidCursor, err.Error())
+	}
+
+	search := util.TextSearch{
+		Query:    request.Q,
+		Prefix:   request.Mode == util.SEARCH_PREFIX,
+		HalfLife: p.searchConf.HalfLife,
+		Cursor:   cursor,
+		Limit:    request.Limit,
+	}
+	if search.Limit <= 0 {
+		search.Limit = p.searchConf.PageSize
+	}
+	if viewer, err := util.GetAuthUser(ctx); err == nil {
+		hidden, err := p.relationChecker.HiddenUserIDs(ctx, viewer.UserId)
+		if err != nil {
+			return nil, err
+		}
+		search.ExcludeUserIDs = hidden
+	}
+
+	found, next, err := p.postRepository.SearchPosts(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &responses.PostSearchPage{Items: p.toPostResponses(found)}
+	if next != nil {
+		page.NextCursor = util.EncodeScoreCursor(next.Score, next.ID)
+	}
+	return page, nil
+}
@@ -1,17 +1,19 @@
 package usecases
 
 import (
-	"bootcamp-content-interaction-service/domains/notifications"
-	notification "bootcamp-content-interaction-service/domains/notifications/entities"
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/media"
 	"bootcamp-content-interaction-service/domains/posts"
 	"bootcamp-content-interaction-service/domains/posts/entities"
 	"bootcamp-content-interaction-service/domains/posts/handlers/http"
 	"bootcamp-content-interaction-service/domains/posts/models/requests"
 	"bootcamp-content-interaction-service/domains/posts/models/responses"
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/infrastructures"
 	sharedResponse "bootcamp-content-interaction-service/shared/models/responses"
 	"bootcamp-content-interaction-service/shared/util"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"context"
@@ -21,61 +23,88 @@ import (
 
 type PostUseCase struct {
 	postRepository posts.PostRepository
+	saveRepository posts.PostSaveRepository
+	exploreRepository posts.ExploreRepository
+	snapshotRepository posts.FeedSnapshotRepository
+	seenRepository posts.SeenRepository
+	imagePipeline media.ImagePipeline
+	uploadClaimer media.UploadClaimer
+	urlSigner *infrastructures.URLSigner
 	userGraphService http.UserGraphService
-	notifRepository notifications.NotificationRepository
+	relationChecker relations.RelationChecker
+	altTextGenerator infrastructures.AltTextGenerator
+	feedConf config.Feed
+	searchConf config.Search
+	logger util.Logger
 }
 
-func NewPostUseCase(postRepo posts.PostRepository, userGraph http.UserGraphService, notifRepository notifications.NotificationRepository) posts.PostUseCase {
+func NewPostUseCase(postRepo posts.PostRepository, saveRepo posts.PostSaveRepository, exploreRepo posts.ExploreRepository, snapshotRepo posts.FeedSnapshotRepository, seenRepo posts.SeenRepository, imagePipeline media.ImagePipeline, uploadClaimer media.UploadClaimer, urlSigner *infrastructures.URLSigner, userGraph http.UserGraphService, relationChecker relations.RelationChecker, altTextGenerator infrastructures.AltTextGenerator, feedConf config.Feed, searchConf config.Search, logger util.Logger) posts.PostUseCase {
     return PostUseCase{
         postRepository: postRepo,
+		saveRepository: saveRepo,
+		exploreRepository: exploreRepo,
+		snapshotRepository: snapshotRepo,
+		seenRepository: seenRepo,
+		imagePipeline: imagePipeline,
+		uploadClaimer: uploadClaimer,
+		urlSigner: urlSigner,
 		userGraphService: userGraph,
-		notifRepository: notifRepository,
+		relationChecker: relationChecker,
+		altTextGenerator: altTextGenerator,
+		feedConf: feedConf.WithDefaults(),
+		searchConf: searchConf.WithDefaults(),
+		logger: logger,
 	}
 }
 
 func (p PostUseCase) UpdatePost(ctx context.Context, postID string, request *requests.UpdatePostRequest) (*responses.PostResponse, error) {
-    existing, err := p.postRepository.FindById(ctx, postID)
+    user, err := util.GetAuthUser(ctx)
     if err != nil {
         return nil, err
     }
-
-    user, err := util.GetAuthUser(ctx)
-    if err != nil {
+    if err := checkAltTexts(request.MediaIDs, request.AltTexts); err != nil {
         return nil, err
     }
 
-    if existing.UserID != uuid.MustParse(user.UserId) {
-        return nil, fmt.Errorf("unauthorized: cannot update someone else's post")
-    }
+    // New images replace the old ones, which are only released once the
+    // post no longer points at them.
+    var claimed, replaced []postImage
+    updated, err := p.postRepository.MutatePost(ctx, postID, func(existing *entities.Post) error {
+        if err := checkEditable(existing, user.UserId, request.Revision); err != nil {
+            return err
+        }
 
-    if request.Caption != "" {
-        existing.Caption = request.Caption
-    }
-    if request.Tags != nil {
-        existing.Tags = request.Tags
-    }
-    if request.ImageURLs != nil {
-        for _, old := range existing.ImageURLs {
-            _ = os.Remove(old)
+        if request.Caption != "" {
+            existing.Caption = request.Caption
+        }
+        if request.Tags != nil {
+            existing.Tags = request.Tags
+        }
+        if existing.ImageRenditions == "" {
+            existing.ImageRenditions = "[]"
+        }
+        if existing.MediaInfo == "" {
+            existing.MediaInfo = "[]"
+        }
+        if len(request.MediaIDs) == 0 {
+            return nil
         }
-        existing.ImageURLs = request.ImageURLs
-    }
-	
-	existing.UpdatedAt = time.Now()
 
-    updated, err := p.postRepository.UpdatePost(ctx, existing)
+        images, err := p.claimImages(ctx, user.UserId, request.MediaIDs, request.AltTexts)
+        if err != nil {
+            return err
+        }
+        claimed = images
+        replaced = postImages(existing)
+        return setPostImages(existing, claimed)
+    })
     if err != nil {
+        p.releaseImages(ctx, claimed)
         return nil, err
     }
+    p.releaseImages(ctx, replaced)
 
-    return &responses.PostResponse{
-        ID:        updated.ID,
-        UserID:    updated.UserID,
-        Caption:   updated.Caption,
-        Tags:      updated.Tags,
-        ImageURLs: updated.ImageURLs,
-        UpdatedAt: updated.UpdatedAt,
-    }, nil
+    return p.toPostResponse(updated), nil
 }
 
 func (p PostUseCase) DeletePost(ctx context.Context, id string) (*sharedResponse.BasicResponse, error) {
@@ -93,16 +122,12 @@ func (p PostUseCase) DeletePost(ctx context.Context, id string) (*sharedResponse
         return nil, fmt.Errorf("unauthorized: cannot delete someone else's post")
     }
 
-    for _, imagePath := range post.ImageURLs {
-        if err := os.Remove(imagePath); err != nil {
-            fmt.Printf("Failed to remove image %s: %v\n", imagePath, err)
-        }
-    }
-
     if err := p.postRepository.DeletePost(ctx, id); err != nil {
         return nil, err
     }
 
+    p.deleteImages(ctx, post)
+
     return &sharedResponse.BasicResponse{
         Data: struct {
             Message string
@@ -119,15 +144,18 @@ func (p PostUseCase) ViewPostById(ctx context.Context, id string) (*responses.Po
 		return nil, err
 	}
 
-	return &responses.PostResponse{
-		ID:        post.ID,
-		UserID:    post.UserID,
-		ImageURLs: post.ImageURLs,
-		Caption:   post.Caption,
-		Tags:      post.Tags,
-		CreatedAt: post.CreatedAt,
-		UpdatedAt: post.UpdatedAt,
-	}, nil
+	// Blocked viewers get the same answer as for a post that does not exist.
+	if viewer, err := util.GetAuthUser(ctx); err == nil {
+		err := p.relationChecker.CheckBlocked(ctx, viewer.UserId, post.UserID.String())
+		if errors.Is(err, relations.ErrBlocked) {
+			return nil, posts.ErrPostNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p.toPostResponse(post), nil
 }
 
 func (p PostUseCase) ViewAllPostByUserId(ctx context.Context) ([]*responses.PostResponse, error) {
@@ -143,44 +171,137 @@ func (p PostUseCase) ViewAllPostByUserId(ctx context.Context) ([]*responses.Post
 		return nil, err
 	}
 
+	// Only the author sees whether their posts are fully described, so
+	// they can go back and fill in what is missing.
 	var responseList []*responses.PostResponse
 	for _, post := range posts {
-		response := &responses.PostResponse{
-			ID:        post.ID,
-			UserID:    post.UserID,
-			ImageURLs: post.ImageURLs,
-			Caption:   post.Caption,
-			Tags:      post.Tags,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
-		}
+		response := p.toPostResponse(post)
+		complete := altTextComplete(postImages(post))
+		response.AltTextComplete = &complete
 		responseList = append(responseList, response)
 	}
 	return responseList, nil
 }
 
-func (p PostUseCase) ViewAllPost(ctx context.Context) ([]*responses.PostResponse, error) {
-	posts, err := p.postRepository.FindAll(ctx)
+// ViewExplore ranks recent posts by decayed engagement and leaves out the
+// viewer's own posts, authors they already follow and users hidden from them.
+// Anonymous viewers get the ranking as is.
+func (p PostUseCase) ViewExplore(ctx context.Context, limit int, offset int) ([]*responses.PostResponse, error) {
+	candidates, err := p.exploreRepository.TopPostIDs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var responseList []*responses.PostResponse
-	for _, post := range posts {
-		response := &responses.PostResponse{
-			ID:        post.ID,
-			UserID:    post.UserID,
-			ImageURLs: post.ImageURLs,
-			Caption:   post.Caption,
-			Tags:      post.Tags,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
+	excluded := make(map[string]bool)
+	if viewer, err := util.GetAuthUser(ctx); err == nil {
+		excluded[viewer.UserId] = true
+
+		hidden, err := p.relationChecker.HiddenUserIDs(ctx, viewer.UserId)
+		if err != nil {
+			return nil, err
 		}
-		responseList = append(responseList, response)
+		for _, id := range hidden {
+			excluded[id] = true
+		}
+
+		// Without the user graph the explore feed may show followed authors,
+		// which beats showing nothing.
+		followingIDs, err := p.userGraphService.GetFollowings(ctx, viewer.UserId)
+		if err != nil && !errors.Is(err, http.ErrUserGraphUnavailable) {
+			return nil, err
+		}
+		for _, id := range followingIDs {
+			excluded[id] = true
+		}
+	}
+
+	found, err := p.postRepository.FindByIDs(ctx, candidates)
+	if err != nil {
+		return nil, err
 	}
+	byID := make(map[string]*entities.Post, len(found))
+	for _, post := range found {
+		byID[post.ID.String()] = post
+	}
+
+	responseList := []*responses.PostResponse{}
+	skipped := 0
+	for _, id := range candidates {
+		post, ok := byID[id]
+		if !ok || excluded[post.UserID.String()] {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		responseList = append(responseList, p.toPostResponse(post))
+		if len(responseList) == limit {
+			break
+		}
+	}
+
 	return responseList, nil
 }
 
+func (p PostUseCase) AddSave(ctx context.Context, postId string) error {
+	userID, postID, err := p.saveTarget(ctx, postId)
+	if err != nil {
+		return err
+	}
+
+	if err := p.relationChecker.CheckPostInteraction(ctx, userID.String(), postId); err != nil {
+		return err
+	}
+
+	return p.saveRepository.AddSave(ctx, userID, postID)
+}
+
+func (p PostUseCase) RemoveSave(ctx context.Context, postId string) error {
+	userID, postID, err := p.saveTarget(ctx, postId)
+	if err != nil {
+		return err
+	}
+
+	return p.saveRepository.RemoveSave(ctx, userID, postID)
+}
+
+func (p PostUseCase) ViewSavedPost(ctx context.Context, limit int, offset int) ([]*responses.PostResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(user.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user id", posts.ErrInvalidPost)
+	}
+
+	saved, err := p.saveRepository.FindSavedPosts(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.toPostResponses(saved), nil
+}
+
+func (p PostUseCase) saveTarget(ctx context.Context, postId string) (uuid.UUID, uuid.UUID, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	userID, err := uuid.Parse(user.UserId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid user id", posts.ErrInvalidPost)
+	}
+	postID, err := uuid.Parse(postId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: post id must be a UUID", posts.ErrInvalidPost)
+	}
+
+	return userID, postID, nil
+}
+
 func (p PostUseCase) CreatePost(ctx context.Context, request *requests.CreatePostRequest) (*responses.PostResponse, error) {
 	user, err := util.GetAuthUser(ctx)
 
@@ -190,79 +311,166 @@ func (p PostUseCase) CreatePost(ctx context.Context, request *requests.CreatePos
 	
 	postObject := &entities.Post{
 		UserID:    uuid.MustParse(user.UserId),
-		ImageURLs: request.ImageURLs,
 		Caption:   request.Caption,
 		Tags:      request.Tags,
 	}
+	images, err := p.claimImages(ctx, user.UserId, request.MediaIDs, request.AltTexts)
+	if err != nil {
+		return nil, err
+	}
+	if err := setPostImages(postObject, images); err != nil {
+		p.releaseImages(ctx, images)
+		return nil, err
+	}
 
 	savedPost, err := p.postRepository.SavePost(ctx, postObject)
 	if err != nil {
+		p.deleteImages(ctx, postObject)
 		return nil, err
 	}
 
-	followers, err := p.userGraphService.GetFollowers(postObject.UserID.String())
+	return p.toPostResponse(savedPost), nil
+}
 
+// ViewPostByUserId builds the personal feed from the user's followings,
+// leaving out users hidden from them by a block or mute, and reports whether
+// the user has seen every recent post. When the user graph service is
+// unavailable it degrades to the newest posts from everyone and reports
+// degraded instead of failing the request.
+func (p PostUseCase) ViewPostByUserId(ctx context.Context, userId string, limit int, offset int) (*responses.FeedPage, error) {
+	hidden, err := p.relationChecker.HiddenUserIDs(ctx, userId)
 	if err != nil {
-		return &responses.PostResponse{
-			ID:        savedPost.ID,
-			UserID:    savedPost.UserID,
-			ImageURLs: savedPost.ImageURLs,
-			Caption:   savedPost.Caption,
-			Tags:      savedPost.Tags,
-			CreatedAt: savedPost.CreatedAt,
-		}, nil
-	}
-
-	for _, follower := range followers {
-		notif := &notification.Notification{
-			SourceUserID: postObject.UserID,
-			RecipientID:  uuid.MustParse(follower),
-			PostID:       savedPost.ID,
-			Type:         "NEW_POST",
-			Content:      savedPost.Caption,
-		}
+		return nil, err
+	}
 
-		_, err := p.notifRepository.SaveNotification(ctx, notif)
+	followingIDs, err := p.userGraphService.GetFollowings(ctx, userId)
+	if errors.Is(err, http.ErrUserGraphUnavailable) {
+		recent, err := p.postRepository.FindRecent(ctx, hidden, limit, offset)
 		if err != nil {
-			continue
+			return nil, err
 		}
+		return &responses.FeedPage{Posts: p.toPostResponses(recent), Degraded: true}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return &responses.PostResponse{
-		ID:        savedPost.ID,
-		UserID:    savedPost.UserID,
-		ImageURLs: savedPost.ImageURLs,
-		Caption:   savedPost.Caption,
-		Tags:      savedPost.Tags,
-		CreatedAt: savedPost.CreatedAt,
-	}, nil
-}
-
-func (p PostUseCase) ViewPostByUserId(ctx context.Context, userId string, limit int, offset int) ([]*responses.PostResponse, error) {
-	followingIDs, err := p.userGraphService.GetFollowings(userId)
+	authorIDs := without(followingIDs, hidden)
+	found, err := p.postRepository.FindByUserIDs(ctx, authorIDs, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	posts, err := p.postRepository.FindByUserIDs(ctx, followingIDs, limit, offset)
+	caughtUp, err := p.caughtUp(ctx, userId, authorIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	var responseList []*responses.PostResponse
-	for _, post := range posts {
-		response := &responses.PostResponse{
-			ID:        post.ID,
-			UserID:    post.UserID,
-			ImageURLs: post.ImageURLs,
-			Caption:   post.Caption,
-			Tags:      post.Tags,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
+	return &responses.FeedPage{Posts: p.toPostResponses(found), CaughtUp: caughtUp}, nil
+}
+
+// ReportImpressions records posts the caller has scrolled past.
+func (p PostUseCase) ReportImpressions(ctx context.Context, request *requests.ImpressionRequest) error {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	return p.seenRepository.MarkSeen(ctx, user.UserId, request.PostIDs)
+}
+
+// caughtUp reports whether userId has seen every post authorIds published
+// within the caught-up window.
+func (p PostUseCase) caughtUp(ctx context.Context, userId string, authorIds []string) (bool, error) {
+	recent, err := p.postRepository.FindFeedCandidates(ctx, authorIds, time.Now().Add(-p.feedConf.CaughtUpWindow), p.feedConf.Candidates)
+	if err != nil {
+		return false, err
+	}
+
+	ids := make([]string, len(recent))
+	for i, post := range recent {
+		ids[i] = post.ID.String()
+	}
+
+	seen, err := p.seenRepository.FindSeen(ctx, userId, ids)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if !seen[id] {
+			return false, nil
 		}
-		responseList = append(responseList, response)
 	}
+	return true, nil
+}
 
-	return responseList, nil
+func without(ids []string, exclude []string) []string {
+	if len(exclude) == 0 {
+		return ids
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+
+	kept := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !excluded[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
 }
 
+func (p PostUseCase) toPostResponse(post *entities.Post) *responses.PostResponse {
+	imageURLs := make([]string, len(post.ImageKeys))
+	for i, key := range post.ImageKeys {
+		imageURLs[i] = p.urlSigner.SignedURL(key)
+	}
+
+	images := postImages(post)
+	renditionURLs := make([]map[string]string, len(images))
+	imageList := make([]*responses.ImageResponse, len(images))
+	for i, image := range images {
+		renditionURLs[i] = make(map[string]string, len(image.Renditions))
+		for name, key := range image.Renditions {
+			renditionURLs[i][name] = p.urlSigner.SignedURL(key)
+		}
+		imageList[i] = &responses.ImageResponse{
+			ID:         image.ID,
+			Type:       image.Type,
+			URL:        renditionURLs[i][util.RENDITION_FULL],
+			Renditions: renditionURLs[i],
+			AltText:    image.AltText,
+			Width:      image.Width,
+			Height:     image.Height,
+			DurationMs: image.DurationMs,
+		}
+		if image.Type == util.MEDIA_VIDEO {
+			imageList[i].URL = renditionURLs[i][util.RENDITION_VIDEO]
+			imageList[i].PosterURL = renditionURLs[i][util.RENDITION_FULL]
+		}
+	}
+
+	return &responses.PostResponse{
+		ID:        post.ID,
+		UserID:    post.UserID,
+		ImageURLs: imageURLs,
+		ImageRenditions: renditionURLs,
+		Images:    imageList,
+		Caption:   post.Caption,
+		Tags:      post.Tags,
+		CreatedAt: post.CreatedAt,
+		UpdatedAt: post.UpdatedAt,
+		Revision:  post.Revision,
+	}
+}
+
+func (p PostUseCase) toPostResponses(found []*entities.Post) []*responses.PostResponse {
+	responseList := []*responses.PostResponse{}
+	for _, post := range found {
+		responseList = append(responseList, p.toPostResponse(post))
+	}
+	return responseList
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/domains/posts/entities"
+	"bootcamp-content-interaction-service/domains/posts/handlers/http"
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakePostRepository serves FindRecent from memory. The embedded interface
+// panics on any other method, so a test fails loudly if the degraded feed
+// starts reading the follow-based queries.
+type fakePostRepository struct {
+	posts.PostRepository
+	recent   []*entities.Post
+	excluded []string
+}
+
+func (r *fakePostRepository) FindRecent(ctx context.Context, excludeUserIds []string, limit int, offset int) ([]*entities.Post, error) {
+	r.excluded = excludeUserIds
+	return r.recent, nil
+}
+
+type fakeRelationChecker struct {
+	relations.RelationChecker
+	hidden []string
+}
+
+func (c *fakeRelationChecker) HiddenUserIDs(ctx context.Context, viewerId string) ([]string, error) {
+	return c.hidden, nil
+}
+
+func TestViewPostByUserIdDegradesWhenUserGraphIsDown(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	graph := http.NewUserGraphHTTP(server.URL, server.Client(), config.UserGraph{
+		Timeout:        100 * time.Millisecond,
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+	})
+
+	post := &entities.Post{ID: uuid.New(), UserID: uuid.New(), Caption: "hello"}
+	postRepo := &fakePostRepository{recent: []*entities.Post{post}}
+	checker := &fakeRelationChecker{hidden: []string{"blocked-user"}}
+
+	useCase := PostUseCase{
+		postRepository:   postRepo,
+		userGraphService: graph,
+		relationChecker:  checker,
+		logger:           util.NewNopLogger(),
+	}
+
+	page, err := useCase.ViewPostByUserId(context.Background(), uuid.NewString(), 10, 0)
+	if err != nil {
+		t.Fatalf("err = %v, want the degraded feed", err)
+	}
+	if !page.Degraded {
+		t.Error("Degraded = false, want true")
+	}
+	if page.CaughtUp {
+		t.Error("CaughtUp = true, want false on a degraded feed")
+	}
+	if len(page.Posts) != 1 || page.Posts[0].ID != post.ID {
+		t.Errorf("posts = %v, want the recent post", page.Posts)
+	}
+	if !reflect.DeepEqual(postRepo.excluded, checker.hidden) {
+		t.Errorf("FindRecent excluded %v, want the hidden users %v", postRepo.excluded, checker.hidden)
+	}
+}
@@ -0,0 +1,100 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/posts"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExploreWorker keeps the explore ranking healthy. On start it rebuilds the
+// ranking from the database when Redis has none, then it periodically drops
+// posts that left the window and rebases the decayed scores.
+type ExploreWorker struct {
+	postRepo    posts.PostRepository
+	exploreRepo posts.ExploreRepository
+	conf        config.Explore
+	logger      util.Logger
+}
+
+func NewExploreWorker(postRepo posts.PostRepository, exploreRepo posts.ExploreRepository, conf config.Explore, logger util.Logger) *ExploreWorker {
+	return &ExploreWorker{
+		postRepo:    postRepo,
+		exploreRepo: exploreRepo,
+		conf:        conf.WithDefaults(),
+		logger:      logger,
+	}
+}
+
+func (w *ExploreWorker) Start(ctx context.Context) {
+	w.seed(ctx)
+
+	ticker := time.NewTicker(w.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.maintain(ctx)
+		}
+	}
+}
+
+// seed scores recent posts from their stored engagement counts. Counts are
+// applied at the post's creation time since individual timestamps are not
+// kept in the ranking; live events refine the scores from then on.
+func (w *ExploreWorker) seed(ctx context.Context) {
+	empty, err := w.exploreRepo.IsEmpty(ctx)
+	if err != nil {
+		w.logger.Error("Failed to check explore ranking", zap.Error(err))
+		return
+	}
+	if !empty {
+		return
+	}
+
+	found, err := w.postRepo.FindEngagementSince(ctx, time.Now().Add(-w.conf.Window))
+	if err != nil {
+		w.logger.Error("Failed to load engagement for explore", zap.Error(err))
+		return
+	}
+
+	for _, post := range found {
+		postId := post.ID.String()
+		if err := w.exploreRepo.AddPost(ctx, postId, post.CreatedAt, w.conf.PostWeight); err != nil {
+			return
+		}
+
+		weight := float64(post.Likes)*w.conf.LikeWeight +
+			float64(post.Comments)*w.conf.CommentWeight +
+			float64(post.Saves)*w.conf.SaveWeight
+		if weight == 0 {
+			continue
+		}
+		if err := w.exploreRepo.Engage(ctx, postId, weight, post.CreatedAt); err != nil {
+			return
+		}
+	}
+
+	w.logger.Info("Seeded explore ranking", zap.Int("posts", len(found)))
+}
+
+func (w *ExploreWorker) maintain(ctx context.Context) {
+	now := time.Now()
+
+	pruned, err := w.exploreRepo.Prune(ctx, now)
+	if err != nil {
+		w.logger.Error("Failed to prune explore ranking", zap.Error(err))
+	} else if pruned > 0 {
+		w.logger.Info("Pruned explore ranking", zap.Int64("posts", pruned))
+	}
+
+	if _, err := w.exploreRepo.Rebase(ctx, now); err != nil {
+		w.logger.Error("Failed to rebase explore ranking", zap.Error(err))
+	}
+}
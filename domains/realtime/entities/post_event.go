@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PostEvent is what the gateway relays to viewers of PostID. ActorID is the
+// user behind the event, if any; viewers who block or mute them do not get it.
+type PostEvent struct {
+	Type      string          `json:"type"`
+	PostID    string          `json:"post_id"`
+	ActorID   string          `json:"actor_id,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"bootcamp-content-interaction-service/domains/comments/models/response"
+	"bootcamp-content-interaction-service/domains/likes"
+	"bootcamp-content-interaction-service/domains/realtime"
+	"bootcamp-content-interaction-service/domains/realtime/entities"
+	"bootcamp-content-interaction-service/shared/events"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+)
+
+// RealtimeEventHandler forwards committed likes and comments to the WebSocket
+// gateway of every instance.
+type RealtimeEventHandler struct {
+	publisher realtime.EventPublisher
+	likesRepo likes.LikesRepository
+}
+
+func NewRealtimeEventHandler(publisher realtime.EventPublisher, likesRepo likes.LikesRepository) *RealtimeEventHandler {
+	return &RealtimeEventHandler{
+		publisher: publisher,
+		likesRepo: likesRepo,
+	}
+}
+
+func (h *RealtimeEventHandler) Register(bus events.EventBus) {
+	bus.Subscribe(events.LIKE_ADDED, "realtime", h.PublishLikeCount)
+	bus.Subscribe(events.LIKE_REMOVED, "realtime", h.PublishLikeCount)
+	bus.Subscribe(events.COMMENT_CREATED, "realtime", h.PublishComment)
+	bus.Subscribe(events.RELATION_CREATED, "realtime", h.PublishRelationChange)
+	bus.Subscribe(events.RELATION_DELETED, "realtime", h.PublishRelationChange)
+}
+
+func (h *RealtimeEventHandler) PublishLikeCount(ctx context.Context, event *events.Event) error {
+	var payload events.LikePayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	count, err := h.likesRepo.CountLikes(ctx, payload.PostID.String())
+	if err != nil {
+		return err
+	}
+
+	likeCount, err := json.Marshal(map[string]interface{}{
+		"post_id":    payload.PostID,
+		"like_count": count,
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, &entities.PostEvent{
+		Type:    util.EVENT_LIKE_COUNT_UPDATED,
+		PostID:  payload.PostID.String(),
+		Payload: likeCount,
+	})
+}
+
+func (h *RealtimeEventHandler) PublishComment(ctx context.Context, event *events.Event) error {
+	var payload events.CommentPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	comment, err := json.Marshal(response.CommentResponse{
+		ID:        payload.CommentID,
+		UserID:    payload.UserID,
+		PostId:    payload.PostID,
+		ReplyId:   payload.ReplyID,
+		CreatedAt: payload.CreatedAt,
+		UpdatedAt: payload.CreatedAt,
+		Msg:       payload.Msg,
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, &entities.PostEvent{
+		Type:    util.EVENT_COMMENT_CREATED,
+		PostID:  payload.PostID.String(),
+		ActorID: payload.UserID.String(),
+		Payload: comment,
+	})
+}
+
+// PublishRelationChange tells every gateway that a block or mute between two
+// users changed, so their open connections can drop what they may no longer
+// see. The event belongs to no post.
+func (h *RealtimeEventHandler) PublishRelationChange(ctx context.Context, event *events.Event) error {
+	var payload events.RelationPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	change, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, &entities.PostEvent{
+		Type:    util.EVENT_RELATION_CHANGED,
+		Payload: change,
+	})
+}
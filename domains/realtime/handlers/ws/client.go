@@ -0,0 +1,282 @@
+package ws
+
+import (
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	writeWait                 = 10 * time.Second
+	pongWait                  = 60 * time.Second
+	pingPeriod                = (pongWait * 9) / 10
+	maxMessageSize            = 4096
+	sendBufferSize            = 64
+	maxSubscriptionsPerClient = 50
+)
+
+type clientMessage struct {
+	Action  string   `json:"action"`
+	PostIDs []string `json:"post_ids"`
+}
+
+type serverMessage struct {
+	Type    string   `json:"type"`
+	PostIDs []string `json:"post_ids,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type Client struct {
+	hub             *Hub
+	relationChecker relations.RelationChecker
+	conn            *websocket.Conn
+	userID          string
+	logger          util.Logger
+
+	// posts is guarded by hub.mu.
+	posts map[string]struct{}
+
+	// hidden holds the users this client blocks, mutes or is blocked by.
+	hiddenMu sync.RWMutex
+	hidden   map[string]struct{}
+
+	send        chan []byte
+	done        chan struct{}
+	closeOnce   sync.Once
+	closeCode   int
+	closeReason string
+}
+
+func newClient(hub *Hub, relationChecker relations.RelationChecker, conn *websocket.Conn, userID string, logger util.Logger) *Client {
+	return &Client{
+		hub:             hub,
+		relationChecker: relationChecker,
+		conn:            conn,
+		userID:          userID,
+		logger:          logger,
+		posts:           make(map[string]struct{}),
+		hidden:          make(map[string]struct{}),
+		send:            make(chan []byte, sendBufferSize),
+		done:            make(chan struct{}),
+	}
+}
+
+// enqueue never blocks the hub. A client whose buffer is full is too slow to
+// keep up with the posts it watches, so it is disconnected and is expected to
+// reconnect and refetch instead of holding back every other viewer.
+func (c *Client) enqueue(message []byte) {
+	select {
+	case <-c.done:
+	case c.send <- message:
+	default:
+		c.logger.Warn("Dropping slow websocket consumer",
+			zap.String("user_id", c.userID),
+		)
+		c.closeWith(websocket.ClosePolicyViolation, "slow consumer")
+	}
+}
+
+func (c *Client) close() {
+	c.closeWith(websocket.CloseNormalClosure, "")
+}
+
+func (c *Client) closeWith(code int, reason string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeReason = reason
+		close(c.done)
+	})
+}
+
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.close()
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var msg clientMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				c.logger.Warn("Websocket read failed",
+					zap.String("user_id", c.userID),
+					zap.Error(err),
+				)
+			}
+			return
+		}
+
+		c.handle(&msg)
+	}
+}
+
+func (c *Client) handle(msg *clientMessage) {
+	var accepted []string
+
+	switch msg.Action {
+	case "subscribe":
+		for _, postID := range msg.PostIDs {
+			if _, err := uuid.Parse(postID); err != nil {
+				c.reply(serverMessage{Type: "error", Error: "invalid post id: " + postID})
+				continue
+			}
+			if err := c.checkPost(postID); err != nil {
+				c.reply(serverMessage{Type: "error", Error: err.Error()})
+				continue
+			}
+			if !c.hub.subscribe(c, postID) {
+				c.reply(serverMessage{Type: "error", Error: "too many subscriptions"})
+				break
+			}
+			accepted = append(accepted, postID)
+		}
+		c.reply(serverMessage{Type: "subscribed", PostIDs: accepted})
+	case "unsubscribe":
+		for _, postID := range msg.PostIDs {
+			c.hub.unsubscribe(c, postID)
+		}
+		c.reply(serverMessage{Type: "unsubscribed", PostIDs: msg.PostIDs})
+	default:
+		c.reply(serverMessage{Type: "error", Error: "unknown action: " + msg.Action})
+	}
+}
+
+// checkPost keeps users blocked from a post's author from watching it. They
+// are told the post does not exist, as they would be over HTTP.
+func (c *Client) checkPost(postID string) error {
+	// The handshake request is over by now, so the check gets its own
+	// context rather than the request's.
+	ctx, cancel := context.WithTimeout(context.Background(), writeWait)
+	defer cancel()
+
+	err := c.relationChecker.CheckPostInteraction(ctx, c.userID, postID)
+	if errors.Is(err, relations.ErrBlocked) {
+		return errors.New("post not found: " + postID)
+	}
+	if err != nil {
+		c.logger.Warn("Failed to check websocket subscription",
+			zap.String("user_id", c.userID),
+			zap.String("post_id", postID),
+			zap.Error(err),
+		)
+		return errors.New("could not subscribe to post: " + postID)
+	}
+	return nil
+}
+
+func (c *Client) setHidden(userIDs []string) {
+	hidden := make(map[string]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		hidden[userID] = struct{}{}
+	}
+
+	c.hiddenMu.Lock()
+	defer c.hiddenMu.Unlock()
+	c.hidden = hidden
+}
+
+func (c *Client) hides(userID string) bool {
+	if userID == "" {
+		return false
+	}
+
+	c.hiddenMu.RLock()
+	defer c.hiddenMu.RUnlock()
+	_, ok := c.hidden[userID]
+	return ok
+}
+
+// refreshRelations reloads the hidden users after a block or mute involving
+// this client changed, and drops the posts it can no longer watch. If either
+// check fails the connection is closed, so the client reconnects and gets
+// checked from scratch rather than keep receiving what it should not.
+func (c *Client) refreshRelations() {
+	ctx, cancel := context.WithTimeout(context.Background(), writeWait)
+	defer cancel()
+
+	hidden, err := c.relationChecker.HiddenUserIDs(ctx, c.userID)
+	if err != nil {
+		c.dropAfterRelationChange(err)
+		return
+	}
+	c.setHidden(hidden)
+
+	var evicted []string
+	for _, postID := range c.hub.subscribedPosts(c) {
+		err := c.relationChecker.CheckPostInteraction(ctx, c.userID, postID)
+		if errors.Is(err, relations.ErrBlocked) {
+			c.hub.unsubscribe(c, postID)
+			evicted = append(evicted, postID)
+			continue
+		}
+		if err != nil {
+			c.dropAfterRelationChange(err)
+			return
+		}
+	}
+
+	if len(evicted) > 0 {
+		c.reply(serverMessage{Type: "unsubscribed", PostIDs: evicted})
+	}
+}
+
+func (c *Client) dropAfterRelationChange(err error) {
+	c.logger.Warn("Failed to recheck websocket relations",
+		zap.String("user_id", c.userID),
+		zap.Error(err),
+	)
+	c.closeWith(websocket.CloseTryAgainLater, "relations changed")
+}
+
+func (c *Client) reply(msg serverMessage) {
+	msgJSON, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	c.enqueue(msgJSON)
+}
+
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			_ = c.conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(c.closeCode, c.closeReason))
+			return
+		case message := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
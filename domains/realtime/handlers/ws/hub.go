@@ -0,0 +1,161 @@
+package ws
+
+import (
+	"bootcamp-content-interaction-service/domains/realtime"
+	"bootcamp-content-interaction-service/domains/realtime/entities"
+	"bootcamp-content-interaction-service/shared/events"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Hub keeps track of which local connections are watching which posts and
+// fans out events received from Redis to them. Every instance runs its own hub
+// so an event published on one instance reaches viewers connected to any other.
+// Events are not relayed to viewers who block or mute their actor, and a
+// relation change makes the connections of both users check again what they
+// are watching.
+type Hub struct {
+	mu            sync.RWMutex
+	clients       map[*Client]struct{}
+	subscriptions map[string]map[*Client]struct{}
+	repo          realtime.RealtimeRepository
+	logger        util.Logger
+}
+
+func NewHub(repo realtime.RealtimeRepository, logger util.Logger) *Hub {
+	return &Hub{
+		clients:       make(map[*Client]struct{}),
+		subscriptions: make(map[string]map[*Client]struct{}),
+		repo:          repo,
+		logger:        logger,
+	}
+}
+
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		err := h.repo.Subscribe(ctx, h.broadcast)
+		if ctx.Err() != nil {
+			return
+		}
+
+		h.logger.Warn("Post event subscription stopped, retrying",
+			zap.Error(err),
+		)
+		time.Sleep(time.Second)
+	}
+}
+
+func (h *Hub) broadcast(event *entities.PostEvent, raw []byte) {
+	if event.Type == util.EVENT_RELATION_CHANGED {
+		h.relationChanged(event)
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.subscriptions[event.PostID] {
+		if client.hides(event.ActorID) {
+			continue
+		}
+		client.enqueue(raw)
+	}
+}
+
+// relationChanged hands the rechecks to the clients' own goroutines, since
+// they hit the database and the hub must keep relaying events meanwhile.
+func (h *Hub) relationChanged(event *entities.PostEvent) {
+	var change events.RelationPayload
+	if err := json.Unmarshal(event.Payload, &change); err != nil {
+		h.logger.Warn("Failed to unmarshal relation change",
+			zap.Error(err),
+		)
+		return
+	}
+
+	userID, targetUserID := change.UserID.String(), change.TargetUserID.String()
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.clients {
+		if client.userID == userID || client.userID == targetUserID {
+			go client.refreshRelations()
+		}
+	}
+}
+
+func (h *Hub) register(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.clients[client] = struct{}{}
+}
+
+func (h *Hub) subscribedPosts(client *Client) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	postIDs := make([]string, 0, len(client.posts))
+	for postID := range client.posts {
+		postIDs = append(postIDs, postID)
+	}
+	return postIDs
+}
+
+func (h *Hub) subscribe(client *Client, postID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := client.posts[postID]; ok {
+		return true
+	}
+	if len(client.posts) >= maxSubscriptionsPerClient {
+		return false
+	}
+
+	clients, ok := h.subscriptions[postID]
+	if !ok {
+		clients = make(map[*Client]struct{})
+		h.subscriptions[postID] = clients
+	}
+	clients[client] = struct{}{}
+	client.posts[postID] = struct{}{}
+
+	return true
+}
+
+func (h *Hub) unsubscribe(client *Client, postID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(client, postID)
+}
+
+func (h *Hub) unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.clients, client)
+	for postID := range client.posts {
+		h.removeLocked(client, postID)
+	}
+}
+
+func (h *Hub) removeLocked(client *Client, postID string) {
+	delete(client.posts, postID)
+
+	clients, ok := h.subscriptions[postID]
+	if !ok {
+		return
+	}
+	delete(clients, client)
+	if len(clients) == 0 {
+		delete(h.subscriptions, postID)
+	}
+}
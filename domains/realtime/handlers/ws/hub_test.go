@@ -0,0 +1,110 @@
+package ws
+
+import (
+	"bootcamp-content-interaction-service/domains/realtime/entities"
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/shared/events"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fakeRelationChecker struct {
+	relations.RelationChecker
+	mu      sync.Mutex
+	hidden  []string
+	blocked map[string]bool
+}
+
+func (c *fakeRelationChecker) HiddenUserIDs(ctx context.Context, viewerId string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hidden, nil
+}
+
+func (c *fakeRelationChecker) CheckPostInteraction(ctx context.Context, userId string, postId string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.blocked[postId] {
+		return relations.ErrBlocked
+	}
+	return nil
+}
+
+func newTestClient(hub *Hub, checker relations.RelationChecker, userID string) *Client {
+	client := newClient(hub, checker, nil, userID, util.NewNopLogger())
+	hub.register(client)
+	return client
+}
+
+func TestHubSkipsEventsFromHiddenActors(t *testing.T) {
+	hub := NewHub(nil, util.NewNopLogger())
+	viewer := newTestClient(hub, &fakeRelationChecker{}, "viewer")
+	viewer.setHidden([]string{"muted"})
+
+	postID := uuid.NewString()
+	hub.subscribe(viewer, postID)
+
+	hub.broadcast(&entities.PostEvent{Type: util.EVENT_COMMENT_CREATED, PostID: postID, ActorID: "muted"}, []byte("muted"))
+	hub.broadcast(&entities.PostEvent{Type: util.EVENT_COMMENT_CREATED, PostID: postID, ActorID: "friend"}, []byte("friend"))
+	hub.broadcast(&entities.PostEvent{Type: util.EVENT_LIKE_COUNT_UPDATED, PostID: postID}, []byte("likes"))
+
+	var got []string
+	for len(viewer.send) > 0 {
+		got = append(got, string(<-viewer.send))
+	}
+	if !reflect.DeepEqual(got, []string{"friend", "likes"}) {
+		t.Errorf("delivered %v, want [friend likes]", got)
+	}
+}
+
+func TestHubEvictsPostsAfterBlock(t *testing.T) {
+	hub := NewHub(nil, util.NewNopLogger())
+	checker := &fakeRelationChecker{blocked: map[string]bool{}}
+	viewerID, authorID := uuid.New(), uuid.New()
+	viewer := newTestClient(hub, checker, viewerID.String())
+	bystander := newTestClient(hub, checker, uuid.NewString())
+
+	blockedPost, otherPost := uuid.NewString(), uuid.NewString()
+	hub.subscribe(viewer, blockedPost)
+	hub.subscribe(viewer, otherPost)
+	hub.subscribe(bystander, blockedPost)
+
+	// The author blocks the viewer.
+	checker.mu.Lock()
+	checker.hidden = []string{authorID.String()}
+	checker.blocked[blockedPost] = true
+	checker.mu.Unlock()
+
+	change, _ := json.Marshal(events.RelationPayload{UserID: authorID, TargetUserID: viewerID, Type: util.RELATION_BLOCK})
+	hub.broadcast(&entities.PostEvent{Type: util.EVENT_RELATION_CHANGED, Payload: change}, nil)
+
+	select {
+	case raw := <-viewer.send:
+		var msg serverMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Type != "unsubscribed" || !reflect.DeepEqual(msg.PostIDs, []string{blockedPost}) {
+			t.Errorf("message = %+v, want the blocked post unsubscribed", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("viewer was not told about the eviction")
+	}
+
+	if got := hub.subscribedPosts(viewer); !reflect.DeepEqual(got, []string{otherPost}) {
+		t.Errorf("viewer watches %v, want only %v", got, otherPost)
+	}
+	if got := hub.subscribedPosts(bystander); len(got) != 1 {
+		t.Errorf("bystander watches %v, want its subscription kept", got)
+	}
+	if !viewer.hides(authorID.String()) {
+		t.Error("viewer still receives events from the author")
+	}
+}
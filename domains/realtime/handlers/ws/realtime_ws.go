@@ -0,0 +1,76 @@
+package ws
+
+import (
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+type RealtimeWs struct {
+	hub             *Hub
+	relationChecker relations.RelationChecker
+	upgrader        websocket.Upgrader
+	logger          util.Logger
+}
+
+func NewRealtimeWs(hub *Hub, relationChecker relations.RelationChecker, logger util.Logger) *RealtimeWs {
+	return &RealtimeWs{
+		hub:             hub,
+		relationChecker: relationChecker,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Connections authenticate with a bearer token rather than a
+			// cookie, so cross-origin handshakes cannot ride on a session.
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		logger: logger,
+	}
+}
+
+func (handler *RealtimeWs) Connect(c *gin.Context) {
+	user, err := util.GetAuthUser(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	hidden, err := handler.relationChecker.HiddenUserIDs(c.Request.Context(), user.UserId)
+	if err != nil {
+		handler.logger.Error("Failed to load hidden users for websocket",
+			zap.String("user_id", user.UserId),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusServiceUnavailable, responses.BasicResponse{Error: "could not load relations"})
+		return
+	}
+
+	conn, err := handler.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		handler.logger.Warn("Websocket upgrade failed",
+			zap.String("user_id", user.UserId),
+			zap.Error(err),
+		)
+		return
+	}
+
+	client := newClient(handler.hub, handler.relationChecker, conn, user.UserId, handler.logger)
+	client.setHidden(hidden)
+	handler.hub.register(client)
+
+	if postIDs := c.Query("post_ids"); postIDs != "" {
+		client.handle(&clientMessage{
+			Action:  "subscribe",
+			PostIDs: strings.Split(postIDs, ","),
+		})
+	}
+
+	go client.writePump()
+	go client.readPump()
+}
@@ -0,0 +1,15 @@
+package realtime
+
+import (
+	"bootcamp-content-interaction-service/domains/realtime/entities"
+	"context"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entities.PostEvent) error
+}
+
+type RealtimeRepository interface {
+	EventPublisher
+	Subscribe(ctx context.Context, handler func(event *entities.PostEvent, raw []byte)) error
+}
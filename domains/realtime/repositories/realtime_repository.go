@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/realtime"
+	"bootcamp-content-interaction-service/domains/realtime/entities"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const channelPrefix = "post_events:"
+
+type RealtimeRepository struct {
+	redisClient *redis.Client
+	logger      util.Logger
+}
+
+func NewRealtimeRepository(redisClient *redis.Client, logger util.Logger) realtime.RealtimeRepository {
+	return &RealtimeRepository{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+func (r *RealtimeRepository) Publish(ctx context.Context, event *entities.PostEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	channel := channelPrefix + event.PostID
+	if err := r.redisClient.Publish(ctx, channel, eventJSON).Err(); err != nil {
+		r.logger.Warn("Redis PUBLISH failed",
+			zap.String("channel", channel),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RealtimeRepository) Subscribe(ctx context.Context, handler func(event *entities.PostEvent, raw []byte)) error {
+	pubsub := r.redisClient.PSubscribe(ctx, channelPrefix+"*")
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	r.logger.Info("Subscribed to post events",
+		zap.String("pattern", channelPrefix+"*"),
+	)
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var event entities.PostEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				r.logger.Warn("Failed to unmarshal post event",
+					zap.String("channel", msg.Channel),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			handler(&event, []byte(msg.Payload))
+		}
+	}
+}
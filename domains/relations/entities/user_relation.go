@@ -0,0 +1,20 @@
+package entities
+
+import (
+	users "bootcamp-content-interaction-service/domains/users/entities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserRelation records that UserID blocked or muted TargetUserID. A mute
+// only hides the target's content from UserID; a block hides it both ways
+// and also stops the two users from interacting.
+type UserRelation struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_user_relation"`
+	User         users.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	TargetUserID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_user_relation;index"`
+	Type         string     `gorm:"type:varchar(10);not null;uniqueIndex:idx_user_relation"`
+	CreatedAt    time.Time  `gorm:"type:timestamp"`
+}
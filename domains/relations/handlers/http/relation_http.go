@@ -0,0 +1,107 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/domains/relations/models/requests"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type RelationHttp struct {
+	relationUc relations.RelationUseCase
+}
+
+func NewRelationHttp(relationUc relations.RelationUseCase) *RelationHttp {
+	return &RelationHttp{
+		relationUc: relationUc,
+	}
+}
+
+func (handler *RelationHttp) CreateBlock(c *gin.Context) {
+	handler.create(c, util.RELATION_BLOCK)
+}
+
+func (handler *RelationHttp) ViewAllBlock(c *gin.Context) {
+	handler.findAll(c, util.RELATION_BLOCK)
+}
+
+func (handler *RelationHttp) DeleteBlock(c *gin.Context) {
+	handler.delete(c, util.RELATION_BLOCK)
+}
+
+func (handler *RelationHttp) CreateMute(c *gin.Context) {
+	handler.create(c, util.RELATION_MUTE)
+}
+
+func (handler *RelationHttp) ViewAllMute(c *gin.Context) {
+	handler.findAll(c, util.RELATION_MUTE)
+}
+
+func (handler *RelationHttp) DeleteMute(c *gin.Context) {
+	handler.delete(c, util.RELATION_MUTE)
+}
+
+func (handler *RelationHttp) create(c *gin.Context, relationType string) {
+	ctx := c.Request.Context()
+	var req requests.RelationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.relationUc.CreateRelation(ctx, relationType, &req)
+	if errors.Is(err, relations.ErrInvalidRelation) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (handler *RelationHttp) findAll(c *gin.Context, relationType string) {
+	ctx := c.Request.Context()
+
+	result, err := handler.relationUc.FindAllRelation(ctx, relationType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *RelationHttp) delete(c *gin.Context, relationType string) {
+	ctx := c.Request.Context()
+
+	err := handler.relationUc.DeleteRelation(ctx, relationType, c.Param("user_id"))
+	if errors.Is(err, relations.ErrInvalidRelation) {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, relations.ErrRelationNotFound) {
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,5 @@
+package requests
+
+type RelationRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
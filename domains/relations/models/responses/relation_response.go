@@ -0,0 +1,8 @@
+package responses
+
+type RelationResponse struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+}
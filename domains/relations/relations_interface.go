@@ -0,0 +1,45 @@
+package relations
+
+import (
+	"bootcamp-content-interaction-service/domains/relations/entities"
+	"bootcamp-content-interaction-service/domains/relations/models/requests"
+	"bootcamp-content-interaction-service/domains/relations/models/responses"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBlocked          = errors.New("interaction blocked")
+	ErrRelationNotFound = errors.New("relation not found")
+	ErrInvalidRelation  = errors.New("invalid relation request")
+)
+
+type RelationUseCase interface {
+	CreateRelation(ctx context.Context, relationType string, request *requests.RelationRequest) (*responses.RelationResponse, error)
+	FindAllRelation(ctx context.Context, relationType string) ([]*responses.RelationResponse, error)
+	DeleteRelation(ctx context.Context, relationType string, targetUserId string) error
+}
+
+// RelationChecker is what other domains use to enforce blocks and mutes.
+type RelationChecker interface {
+	// HiddenUserIDs lists the users whose content viewerId must not see:
+	// everyone viewerId blocked or muted and everyone who blocked viewerId.
+	HiddenUserIDs(ctx context.Context, viewerId string) ([]string, error)
+	// CheckBlocked returns ErrBlocked when either user blocked the other.
+	CheckBlocked(ctx context.Context, userId string, otherUserId string) error
+	// CheckPostInteraction returns ErrBlocked when userId and the author of
+	// postId blocked each other.
+	CheckPostInteraction(ctx context.Context, userId string, postId string) error
+}
+
+type RelationRepository interface {
+	SaveRelation(ctx context.Context, relation *entities.UserRelation) (*entities.UserRelation, error)
+	FindRelations(ctx context.Context, userId string, relationType string) ([]*entities.UserRelation, error)
+	DeleteRelation(ctx context.Context, userId string, targetUserId string, relationType string) error
+	FindHiddenUserIDs(ctx context.Context, viewerId string) ([]string, error)
+	IsBlocked(ctx context.Context, userId string, otherUserId string) (bool, error)
+	IsBlockedFromPost(ctx context.Context, userId string, postId string) (bool, error)
+	FindHidingRecipients(ctx context.Context, sourceUserId uuid.UUID, recipientIds []uuid.UUID) (map[uuid.UUID]bool, error)
+}
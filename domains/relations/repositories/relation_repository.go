@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/outbox"
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/domains/relations/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/events"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const hiddenUsersTTL = 5 * time.Minute
+
+type RelationRepository struct {
+	db         infrastructures.Database
+	redisCache *redis.Client
+	logger     util.Logger
+	outbox     outbox.OutboxRepository
+}
+
+func NewRelationRepository(db infrastructures.Database, redisClient *redis.Client, logger util.Logger, outboxRepo outbox.OutboxRepository) relations.RelationRepository {
+	return RelationRepository{
+		db:         db,
+		redisCache: redisClient,
+		logger:     logger,
+		outbox:     outboxRepo,
+	}
+}
+
+func (r RelationRepository) SaveRelation(ctx context.Context, relation *entities.UserRelation) (*entities.UserRelation, error) {
+	relation.ID = uuid.New()
+	relation.CreatedAt = time.Now()
+
+	created := false
+	err := r.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("User").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(relation)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		created = true
+		return r.outbox.Append(tx, events.RELATION_CREATED, relation.ID, events.RelationPayload{
+			UserID:       relation.UserID,
+			TargetUserID: relation.TargetUserID,
+			Type:         relation.Type,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A repeated block or mute keeps the original row.
+	if !created {
+		var existing entities.UserRelation
+		err := r.db.GetInstance().WithContext(ctx).
+			Where("user_id = ? AND target_user_id = ? AND type = ?", relation.UserID, relation.TargetUserID, relation.Type).
+			First(&existing).Error
+		if err != nil {
+			return nil, err
+		}
+		return &existing, nil
+	}
+
+	r.invalidate(ctx, relation.UserID.String(), relation.TargetUserID.String())
+	r.logger.Info("User relation created",
+		zap.String("user_id", relation.UserID.String()),
+		zap.String("target_user_id", relation.TargetUserID.String()),
+		zap.String("type", relation.Type),
+	)
+
+	return relation, nil
+}
+
+func (r RelationRepository) FindRelations(ctx context.Context, userId string, relationType string) ([]*entities.UserRelation, error) {
+	var found []*entities.UserRelation
+	result := r.db.GetInstance().WithContext(ctx).
+		Where("user_id = ? AND type = ?", userId, relationType).
+		Order("created_at DESC").
+		Find(&found)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return found, nil
+}
+
+func (r RelationRepository) DeleteRelation(ctx context.Context, userId string, targetUserId string, relationType string) error {
+	err := r.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deleted []entities.UserRelation
+		result := tx.Clauses(clause.Returning{}).
+			Where("user_id = ? AND target_user_id = ? AND type = ?", userId, targetUserId, relationType).
+			Delete(&deleted)
+		if result.Error != nil {
+			return result.Error
+		}
+		if len(deleted) == 0 {
+			return relations.ErrRelationNotFound
+		}
+
+		return r.outbox.Append(tx, events.RELATION_DELETED, deleted[0].ID, events.RelationPayload{
+			UserID:       deleted[0].UserID,
+			TargetUserID: deleted[0].TargetUserID,
+			Type:         deleted[0].Type,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, userId, targetUserId)
+	return nil
+}
+
+// FindHiddenUserIDs is read on every feed and comment listing, so the set is
+// cached per viewer and dropped whenever a relation involving them changes.
+func (r RelationRepository) FindHiddenUserIDs(ctx context.Context, viewerId string) ([]string, error) {
+	cacheKey := hiddenUsersKey(viewerId)
+
+	cached, err := r.redisCache.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var ids []string
+		if err := json.Unmarshal([]byte(cached), &ids); err == nil {
+			return ids, nil
+		}
+	} else if err != redis.Nil {
+		r.logger.Error("Redis GET operation failed", zap.String("cache_key", cacheKey), zap.Error(err))
+	}
+
+	ids := []string{}
+	result := r.db.GetInstance().WithContext(ctx).Raw(`
+		SELECT target_user_id::text FROM user_relations WHERE user_id = ?
+		UNION
+		SELECT user_id::text FROM user_relations WHERE target_user_id = ? AND type = ?`,
+		viewerId, viewerId, util.RELATION_BLOCK,
+	).Scan(&ids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if bytes, err := json.Marshal(ids); err == nil {
+		if err := r.redisCache.Set(ctx, cacheKey, bytes, hiddenUsersTTL).Err(); err != nil {
+			r.logger.Error("Failed to set cache", zap.String("cache_key", cacheKey), zap.Error(err))
+		}
+	}
+
+	return ids, nil
+}
+
+func (r RelationRepository) IsBlocked(ctx context.Context, userId string, otherUserId string) (bool, error) {
+	var count int64
+	result := r.db.GetInstance().WithContext(ctx).
+		Model(&entities.UserRelation{}).
+		Where("type = ?", util.RELATION_BLOCK).
+		Where("(user_id = ? AND target_user_id = ?) OR (user_id = ? AND target_user_id = ?)", userId, otherUserId, otherUserId, userId).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+func (r RelationRepository) IsBlockedFromPost(ctx context.Context, userId string, postId string) (bool, error) {
+	var blocked bool
+	result := r.db.GetInstance().WithContext(ctx).Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM user_relations r
+			JOIN posts p ON p.id = ?
+			WHERE r.type = ?
+			AND ((r.user_id = p.user_id AND r.target_user_id = ?) OR (r.user_id = ? AND r.target_user_id = p.user_id))
+		)`,
+		postId, util.RELATION_BLOCK, userId, userId,
+	).Scan(&blocked)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return blocked, nil
+}
+
+// FindHidingRecipients returns the recipients that must not hear from
+// sourceUserId: those who blocked or muted it and those it blocked.
+func (r RelationRepository) FindHidingRecipients(ctx context.Context, sourceUserId uuid.UUID, recipientIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	hiding := make(map[uuid.UUID]bool)
+	if len(recipientIds) == 0 {
+		return hiding, nil
+	}
+
+	var ids []uuid.UUID
+	result := r.db.GetInstance().WithContext(ctx).Raw(`
+		SELECT user_id FROM user_relations WHERE target_user_id = ? AND user_id IN ?
+		UNION
+		SELECT target_user_id FROM user_relations WHERE user_id = ? AND type = ? AND target_user_id IN ?`,
+		sourceUserId, recipientIds, sourceUserId, util.RELATION_BLOCK, recipientIds,
+	).Scan(&ids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, id := range ids {
+		hiding[id] = true
+	}
+	return hiding, nil
+}
+
+func (r RelationRepository) invalidate(ctx context.Context, userIds ...string) {
+	keys := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		keys = append(keys, hiddenUsersKey(userId))
+	}
+	if err := r.redisCache.Del(ctx, keys...).Err(); err != nil {
+		r.logger.Error("Failed to invalidate hidden users cache", zap.Strings("keys", keys), zap.Error(err))
+	}
+}
+
+func hiddenUsersKey(userId string) string {
+	return "relations:hidden:" + userId
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/relations"
+	"context"
+	"fmt"
+)
+
+type RelationChecker struct {
+	relationRepo relations.RelationRepository
+}
+
+func NewRelationChecker(relationRepo relations.RelationRepository) relations.RelationChecker {
+	return RelationChecker{relationRepo: relationRepo}
+}
+
+func (r RelationChecker) HiddenUserIDs(ctx context.Context, viewerId string) ([]string, error) {
+	if viewerId == "" {
+		return nil, nil
+	}
+	return r.relationRepo.FindHiddenUserIDs(ctx, viewerId)
+}
+
+func (r RelationChecker) CheckBlocked(ctx context.Context, userId string, otherUserId string) error {
+	if userId == "" || otherUserId == "" || userId == otherUserId {
+		return nil
+	}
+
+	blocked, err := r.relationRepo.IsBlocked(ctx, userId, otherUserId)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return relations.ErrBlocked
+	}
+	return nil
+}
+
+func (r RelationChecker) CheckPostInteraction(ctx context.Context, userId string, postId string) error {
+	blocked, err := r.relationRepo.IsBlockedFromPost(ctx, userId, postId)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%w: cannot interact with this post", relations.ErrBlocked)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/relations"
+	"bootcamp-content-interaction-service/domains/relations/entities"
+	"bootcamp-content-interaction-service/domains/relations/models/requests"
+	"bootcamp-content-interaction-service/domains/relations/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RelationUseCase struct {
+	relationRepo relations.RelationRepository
+}
+
+func NewRelationUseCase(relationRepo relations.RelationRepository) relations.RelationUseCase {
+	return RelationUseCase{relationRepo: relationRepo}
+}
+
+func (r RelationUseCase) CreateRelation(ctx context.Context, relationType string, request *requests.RelationRequest) (*responses.RelationResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(user.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user id", relations.ErrInvalidRelation)
+	}
+	targetID, err := uuid.Parse(request.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: user_id must be a UUID", relations.ErrInvalidRelation)
+	}
+	if userID == targetID {
+		return nil, fmt.Errorf("%w: cannot %s yourself", relations.ErrInvalidRelation, verb(relationType))
+	}
+
+	saved, err := r.relationRepo.SaveRelation(ctx, &entities.UserRelation{
+		UserID:       userID,
+		TargetUserID: targetID,
+		Type:         relationType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRelationResponse(saved), nil
+}
+
+func (r RelationUseCase) FindAllRelation(ctx context.Context, relationType string) ([]*responses.RelationResponse, error) {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.relationRepo.FindRelations(ctx, user.UserId, relationType)
+	if err != nil {
+		return nil, err
+	}
+
+	responseList := []*responses.RelationResponse{}
+	for _, relation := range found {
+		responseList = append(responseList, toRelationResponse(relation))
+	}
+	return responseList, nil
+}
+
+func (r RelationUseCase) DeleteRelation(ctx context.Context, relationType string, targetUserId string) error {
+	user, err := util.GetAuthUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uuid.Parse(targetUserId); err != nil {
+		return fmt.Errorf("%w: user id must be a UUID", relations.ErrInvalidRelation)
+	}
+
+	return r.relationRepo.DeleteRelation(ctx, user.UserId, targetUserId, relationType)
+}
+
+func verb(relationType string) string {
+	if relationType == util.RELATION_MUTE {
+		return "mute"
+	}
+	return "block"
+}
+
+func toRelationResponse(relation *entities.UserRelation) *responses.RelationResponse {
+	return &responses.RelationResponse{
+		ID:        relation.ID.String(),
+		UserID:    relation.TargetUserID.String(),
+		Type:      relation.Type,
+		CreatedAt: relation.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDelivery is one event queued for one subscription. Body is the exact
+// request body that gets signed and sent, so retries and replays are
+// byte-for-byte identical. The (subscription, event) pair is unique because
+// the outbox may hand the same event over more than once.
+type WebhookDelivery struct {
+	ID             uuid.UUID           `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	SubscriptionID uuid.UUID           `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event"`
+	Subscription   WebhookSubscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE"`
+	EventID        string              `gorm:"type:varchar(64);not null;uniqueIndex:idx_webhook_delivery_event"`
+	EventType      string              `gorm:"type:varchar(100);not null"`
+	Body           string              `gorm:"type:jsonb;not null"`
+	Status         string              `gorm:"type:varchar(20);not null"`
+	Attempts       int                 `gorm:"not null;default:0"`
+	LastStatusCode int
+	LastError      string           `gorm:"type:text"`
+	NextAttemptAt  time.Time        `gorm:"type:timestamp;index:idx_webhook_delivery_due,where:status = 'PENDING'"`
+	DeliveredAt    *time.Time       `gorm:"type:timestamp"`
+	CreatedAt      time.Time        `gorm:"type:timestamp"`
+	UpdatedAt      time.Time        `gorm:"type:timestamp"`
+	Log            []WebhookAttempt `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookAttempt records a single HTTP call made for a delivery.
+type WebhookAttempt struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	DeliveryID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	StatusCode   int
+	Error        string    `gorm:"type:text"`
+	ResponseBody string    `gorm:"type:text"`
+	DurationMs   int64     `gorm:"not null;default:0"`
+	AttemptedAt  time.Time `gorm:"type:timestamp"`
+}
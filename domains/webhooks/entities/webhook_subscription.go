@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WebhookSubscription is owned by the internal service that registered it.
+// An empty EventTypes filter subscribes to every supported event.
+type WebhookSubscription struct {
+	ID         uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Owner      string         `gorm:"type:varchar(100);not null;index"`
+	TargetURL  string         `gorm:"type:text;not null"`
+	EventTypes pq.StringArray `gorm:"type:text[]"`
+	Secret     string         `gorm:"type:varchar(255);not null"`
+	Active     bool           `gorm:"not null;default:true"`
+	CreatedAt  time.Time      `gorm:"type:timestamp"`
+	UpdatedAt  time.Time      `gorm:"type:timestamp"`
+}
+
+func (s *WebhookSubscription) Accepts(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, accepted := range s.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
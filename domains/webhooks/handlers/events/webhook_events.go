@@ -0,0 +1,65 @@
+package events
+
+import (
+	"bootcamp-content-interaction-service/domains/webhooks"
+	"bootcamp-content-interaction-service/domains/webhooks/entities"
+	"bootcamp-content-interaction-service/shared/events"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type WebhookEventHandler struct {
+	repo webhooks.WebhookRepository
+}
+
+func NewWebhookEventHandler(repo webhooks.WebhookRepository) *WebhookEventHandler {
+	return &WebhookEventHandler{repo: repo}
+}
+
+func (h *WebhookEventHandler) Register(bus events.EventBus) {
+	for _, eventType := range webhooks.SupportedEvents {
+		bus.Subscribe(eventType, "webhooks", h.OnEvent)
+	}
+}
+
+type webhookBody struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// OnEvent queues one delivery per matching subscription. The HTTP calls are
+// left to the delivery worker so a slow receiver never holds up the outbox.
+func (h *WebhookEventHandler) OnEvent(ctx context.Context, event *events.Event) error {
+	subscriptions, err := h.repo.FindActiveSubscriptions(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(webhookBody{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*entities.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, &entities.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Body:           string(body),
+		})
+	}
+
+	return h.repo.EnqueueDeliveries(ctx, deliveries)
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"bootcamp-content-interaction-service/domains/webhooks"
+	"bootcamp-content-interaction-service/domains/webhooks/models/requests"
+	"bootcamp-content-interaction-service/shared/models/responses"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type WebhookHttp struct {
+	webhookUc webhooks.WebhookUseCase
+}
+
+func NewWebhookHttp(webhookUc webhooks.WebhookUseCase) *WebhookHttp {
+	return &WebhookHttp{
+		webhookUc: webhookUc,
+	}
+}
+
+func (handler *WebhookHttp) CreateSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.WebhookSubscriptionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.webhookUc.CreateSubscription(ctx, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (handler *WebhookHttp) ViewAllSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := handler.webhookUc.FindAllSubscription(ctx)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *WebhookHttp) ViewSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := handler.webhookUc.FindSubscription(ctx, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *WebhookHttp) UpdateSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.WebhookSubscriptionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, req); err != nil {
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := handler.webhookUc.UpdateSubscription(ctx, c.Param("id"), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *WebhookHttp) DeleteSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if err := handler.webhookUc.DeleteSubscription(ctx, c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BasicResponse{Data: "Webhook " + c.Param("id") + " deleted"})
+}
+
+func (handler *WebhookHttp) ViewAllDelivery(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	result, err := handler.webhookUc.FindAllDelivery(ctx, c.Param("id"), limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *WebhookHttp) ViewDelivery(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := handler.webhookUc.FindDelivery(ctx, c.Param("delivery_id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (handler *WebhookHttp) ReplayDelivery(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := handler.webhookUc.ReplayDelivery(ctx, c.Param("delivery_id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, result)
+}
+
+func writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, webhooks.ErrInvalidWebhook):
+		c.JSON(http.StatusBadRequest, responses.BasicResponse{Error: err.Error()})
+	case errors.Is(err, webhooks.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, responses.BasicResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, responses.BasicResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,8 @@
+package requests
+
+type WebhookSubscriptionRequest struct {
+	TargetURL  string   `json:"target_url" validate:"required,url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16"`
+	Active     *bool    `json:"active"`
+}
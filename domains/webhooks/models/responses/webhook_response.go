@@ -0,0 +1,34 @@
+package responses
+
+type WebhookSubscriptionResponse struct {
+	ID         string   `json:"id"`
+	TargetURL  string   `json:"target_url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret,omitempty"`
+	Active     bool     `json:"active"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string                    `json:"id"`
+	SubscriptionID string                    `json:"subscription_id"`
+	EventID        string                    `json:"event_id"`
+	EventType      string                    `json:"event_type"`
+	Status         string                    `json:"status"`
+	Attempts       int                       `json:"attempts"`
+	LastStatusCode int                       `json:"last_status_code,omitempty"`
+	LastError      string                    `json:"last_error,omitempty"`
+	NextAttemptAt  string                    `json:"next_attempt_at,omitempty"`
+	DeliveredAt    string                    `json:"delivered_at,omitempty"`
+	CreatedAt      string                    `json:"created_at"`
+	Log            []*WebhookAttemptResponse `json:"log,omitempty"`
+}
+
+type WebhookAttemptResponse struct {
+	StatusCode   int    `json:"status_code,omitempty"`
+	Error        string `json:"error,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+	DurationMs   int64  `json:"duration_ms"`
+	AttemptedAt  string `json:"attempted_at"`
+}
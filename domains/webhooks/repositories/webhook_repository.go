@@ -0,0 +1,254 @@
+package repositories
+
+import (
+	"bootcamp-content-interaction-service/domains/webhooks"
+	"bootcamp-content-interaction-service/domains/webhooks/entities"
+	"bootcamp-content-interaction-service/infrastructures"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db     infrastructures.Database
+	logger util.Logger
+}
+
+func NewWebhookRepository(db infrastructures.Database, logger util.Logger) webhooks.WebhookRepository {
+	return WebhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (w WebhookRepository) SaveSubscription(ctx context.Context, subscription *entities.WebhookSubscription) (*entities.WebhookSubscription, error) {
+	now := time.Now()
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
+		subscription.CreatedAt = now
+	}
+	subscription.UpdatedAt = now
+
+	result := w.db.GetInstance().WithContext(ctx).Save(subscription)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscription, nil
+}
+
+func (w WebhookRepository) FindSubscriptions(ctx context.Context, owner string) ([]*entities.WebhookSubscription, error) {
+	var subscriptions []*entities.WebhookSubscription
+	result := w.db.GetInstance().WithContext(ctx).
+		Where("owner = ?", owner).
+		Order("created_at ASC").
+		Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscriptions, nil
+}
+
+func (w WebhookRepository) FindSubscription(ctx context.Context, owner string, id string) (*entities.WebhookSubscription, error) {
+	var subscription entities.WebhookSubscription
+	result := w.db.GetInstance().WithContext(ctx).
+		Where("id = ? AND owner = ?", id, owner).
+		First(&subscription)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, webhooks.ErrWebhookNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &subscription, nil
+}
+
+func (w WebhookRepository) DeleteSubscription(ctx context.Context, owner string, id string) error {
+	result := w.db.GetInstance().WithContext(ctx).
+		Where("id = ? AND owner = ?", id, owner).
+		Delete(&entities.WebhookSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return webhooks.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (w WebhookRepository) FindActiveSubscriptions(ctx context.Context, eventType string) ([]*entities.WebhookSubscription, error) {
+	var subscriptions []*entities.WebhookSubscription
+	result := w.db.GetInstance().WithContext(ctx).
+		Where("active = ? AND (event_types IS NULL OR cardinality(event_types) = 0 OR ? = ANY(event_types))", true, eventType).
+		Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscriptions, nil
+}
+
+// EnqueueDeliveries skips deliveries that already exist for the same
+// subscription and event, which makes a redelivered outbox event harmless.
+func (w WebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, delivery := range deliveries {
+		delivery.ID = uuid.New()
+		delivery.Status = util.WEBHOOK_PENDING
+		delivery.NextAttemptAt = now
+		delivery.CreatedAt = now
+		delivery.UpdatedAt = now
+	}
+
+	result := w.db.GetInstance().WithContext(ctx).
+		Omit("Subscription").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	w.logger.Info("Webhook deliveries queued",
+		zap.Int64("count", result.RowsAffected),
+	)
+
+	return nil
+}
+
+func (w WebhookRepository) FindDeliveries(ctx context.Context, subscriptionId string, limit int) ([]*entities.WebhookDelivery, error) {
+	var deliveries []*entities.WebhookDelivery
+	result := w.db.GetInstance().WithContext(ctx).
+		Where("subscription_id = ?", subscriptionId).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deliveries, nil
+}
+
+func (w WebhookRepository) FindDelivery(ctx context.Context, owner string, id string) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	result := w.db.GetInstance().WithContext(ctx).
+		Joins("Subscription").
+		Preload("Log", func(db *gorm.DB) *gorm.DB {
+			return db.Order("attempted_at ASC")
+		}).
+		Where("webhook_deliveries.id = ? AND \"Subscription\".owner = ?", id, owner).
+		First(&delivery)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, webhooks.ErrWebhookNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &delivery, nil
+}
+
+// ResetDelivery puts a delivery back in the queue with a fresh attempt budget.
+// Earlier attempts stay in the log.
+func (w WebhookRepository) ResetDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	now := time.Now()
+	delivery.Status = util.WEBHOOK_PENDING
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.UpdatedAt = now
+
+	return w.db.GetInstance().WithContext(ctx).
+		Model(&entities.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"updated_at":      delivery.UpdatedAt,
+		}).Error
+}
+
+// ClaimDue leases up to limit due deliveries by pushing their next attempt
+// past the lease, so the HTTP calls happen outside any transaction and a
+// crashed worker's deliveries come back once the lease runs out.
+func (w WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	var claimed []*entities.WebhookDelivery
+
+	now := time.Now()
+	result := w.db.GetInstance().WithContext(ctx).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), util.WEBHOOK_PENDING, now, limit).
+		Scan(&claimed)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	subscriptionIDs := make([]uuid.UUID, 0, len(claimed))
+	for _, delivery := range claimed {
+		subscriptionIDs = append(subscriptionIDs, delivery.SubscriptionID)
+	}
+
+	var subscriptions []*entities.WebhookSubscription
+	result = w.db.GetInstance().WithContext(ctx).Where("id IN ?", subscriptionIDs).Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	byID := make(map[uuid.UUID]*entities.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byID[subscription.ID] = subscription
+	}
+	for _, delivery := range claimed {
+		if subscription, ok := byID[delivery.SubscriptionID]; ok {
+			delivery.Subscription = *subscription
+		}
+	}
+
+	return claimed, nil
+}
+
+func (w WebhookRepository) SaveAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error {
+	return w.db.GetInstance().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		attempt.ID = uuid.New()
+		attempt.DeliveryID = delivery.ID
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+
+		delivery.UpdatedAt = time.Now()
+		return tx.Model(&entities.WebhookDelivery{}).
+			Where("id = ?", delivery.ID).
+			Updates(map[string]interface{}{
+				"status":           delivery.Status,
+				"attempts":         delivery.Attempts,
+				"last_status_code": delivery.LastStatusCode,
+				"last_error":       delivery.LastError,
+				"next_attempt_at":  delivery.NextAttemptAt,
+				"delivered_at":     delivery.DeliveredAt,
+				"updated_at":       delivery.UpdatedAt,
+			}).Error
+	})
+}
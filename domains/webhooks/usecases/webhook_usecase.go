@@ -0,0 +1,290 @@
+package usecases
+
+import (
+	"bootcamp-content-interaction-service/domains/webhooks"
+	"bootcamp-content-interaction-service/domains/webhooks/entities"
+	"bootcamp-content-interaction-service/domains/webhooks/models/requests"
+	"bootcamp-content-interaction-service/domains/webhooks/models/responses"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+type WebhookUseCase struct {
+	repo webhooks.WebhookRepository
+}
+
+func NewWebhookUseCase(repo webhooks.WebhookRepository) webhooks.WebhookUseCase {
+	return WebhookUseCase{repo: repo}
+}
+
+func (w WebhookUseCase) CreateSubscription(ctx context.Context, request *requests.WebhookSubscriptionRequest) (*responses.WebhookSubscriptionResponse, error) {
+	owner, err := util.GetCallingService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSubscription(request); err != nil {
+		return nil, err
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := &entities.WebhookSubscription{
+		Owner:      owner,
+		TargetURL:  request.TargetURL,
+		EventTypes: request.EventTypes,
+		Secret:     secret,
+		Active:     request.Active == nil || *request.Active,
+	}
+
+	saved, err := w.repo.SaveSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret is only ever returned when the subscription is created.
+	response := toSubscriptionResponse(saved)
+	response.Secret = saved.Secret
+	return response, nil
+}
+
+func (w WebhookUseCase) FindAllSubscription(ctx context.Context) ([]*responses.WebhookSubscriptionResponse, error) {
+	owner, err := util.GetCallingService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := w.repo.FindSubscriptions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	responseList := []*responses.WebhookSubscriptionResponse{}
+	for _, subscription := range subscriptions {
+		responseList = append(responseList, toSubscriptionResponse(subscription))
+	}
+	return responseList, nil
+}
+
+func (w WebhookUseCase) FindSubscription(ctx context.Context, subscriptionId string) (*responses.WebhookSubscriptionResponse, error) {
+	subscription, err := w.findSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubscriptionResponse(subscription), nil
+}
+
+func (w WebhookUseCase) UpdateSubscription(ctx context.Context, subscriptionId string, request *requests.WebhookSubscriptionRequest) (*responses.WebhookSubscriptionResponse, error) {
+	subscription, err := w.findSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSubscription(request); err != nil {
+		return nil, err
+	}
+
+	subscription.TargetURL = request.TargetURL
+	subscription.EventTypes = request.EventTypes
+	if request.Secret != "" {
+		subscription.Secret = request.Secret
+	}
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+
+	saved, err := w.repo.SaveSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubscriptionResponse(saved), nil
+}
+
+func (w WebhookUseCase) DeleteSubscription(ctx context.Context, subscriptionId string) error {
+	owner, err := util.GetCallingService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uuid.Parse(subscriptionId); err != nil {
+		return fmt.Errorf("%w: webhook id must be a UUID", webhooks.ErrInvalidWebhook)
+	}
+
+	return w.repo.DeleteSubscription(ctx, owner, subscriptionId)
+}
+
+func (w WebhookUseCase) FindAllDelivery(ctx context.Context, subscriptionId string, limit int) ([]*responses.WebhookDeliveryResponse, error) {
+	subscription, err := w.findSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+
+	deliveries, err := w.repo.FindDeliveries(ctx, subscription.ID.String(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responseList := []*responses.WebhookDeliveryResponse{}
+	for _, delivery := range deliveries {
+		responseList = append(responseList, toDeliveryResponse(delivery))
+	}
+	return responseList, nil
+}
+
+func (w WebhookUseCase) FindDelivery(ctx context.Context, deliveryId string) (*responses.WebhookDeliveryResponse, error) {
+	delivery, err := w.findDelivery(ctx, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeliveryResponse(delivery), nil
+}
+
+func (w WebhookUseCase) ReplayDelivery(ctx context.Context, deliveryId string) (*responses.WebhookDeliveryResponse, error) {
+	delivery, err := w.findDelivery(ctx, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Status == util.WEBHOOK_PENDING {
+		return nil, fmt.Errorf("%w: delivery is already queued", webhooks.ErrInvalidWebhook)
+	}
+	if !delivery.Subscription.Active {
+		return nil, fmt.Errorf("%w: subscription is inactive", webhooks.ErrInvalidWebhook)
+	}
+
+	if err := w.repo.ResetDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return toDeliveryResponse(delivery), nil
+}
+
+func (w WebhookUseCase) findSubscription(ctx context.Context, subscriptionId string) (*entities.WebhookSubscription, error) {
+	owner, err := util.GetCallingService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uuid.Parse(subscriptionId); err != nil {
+		return nil, fmt.Errorf("%w: webhook id must be a UUID", webhooks.ErrInvalidWebhook)
+	}
+
+	return w.repo.FindSubscription(ctx, owner, subscriptionId)
+}
+
+func (w WebhookUseCase) findDelivery(ctx context.Context, deliveryId string) (*entities.WebhookDelivery, error) {
+	owner, err := util.GetCallingService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uuid.Parse(deliveryId); err != nil {
+		return nil, fmt.Errorf("%w: delivery id must be a UUID", webhooks.ErrInvalidWebhook)
+	}
+
+	return w.repo.FindDelivery(ctx, owner, deliveryId)
+}
+
+func validateSubscription(request *requests.WebhookSubscriptionRequest) error {
+	target, err := url.Parse(request.TargetURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: target_url must be an absolute http(s) URL", webhooks.ErrInvalidWebhook)
+	}
+
+	for _, eventType := range request.EventTypes {
+		supported := false
+		for _, candidate := range webhooks.SupportedEvents {
+			if eventType == candidate {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("%w: unsupported event type %q", webhooks.ErrInvalidWebhook, eventType)
+		}
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func toSubscriptionResponse(subscription *entities.WebhookSubscription) *responses.WebhookSubscriptionResponse {
+	eventTypes := []string(subscription.EventTypes)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &responses.WebhookSubscriptionResponse{
+		ID:         subscription.ID.String(),
+		TargetURL:  subscription.TargetURL,
+		EventTypes: eventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  subscription.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toDeliveryResponse(delivery *entities.WebhookDelivery) *responses.WebhookDeliveryResponse {
+	response := &responses.WebhookDeliveryResponse{
+		ID:             delivery.ID.String(),
+		SubscriptionID: delivery.SubscriptionID.String(),
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == util.WEBHOOK_PENDING {
+		response.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		response.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	for _, attempt := range delivery.Log {
+		response.Log = append(response.Log, &responses.WebhookAttemptResponse{
+			StatusCode:   attempt.StatusCode,
+			Error:        attempt.Error,
+			ResponseBody: attempt.ResponseBody,
+			DurationMs:   attempt.DurationMs,
+			AttemptedAt:  attempt.AttemptedAt.Format(time.RFC3339),
+		})
+	}
+	return response
+}
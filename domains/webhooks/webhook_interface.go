@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"bootcamp-content-interaction-service/domains/webhooks/entities"
+	"bootcamp-content-interaction-service/domains/webhooks/models/requests"
+	"bootcamp-content-interaction-service/domains/webhooks/models/responses"
+	"bootcamp-content-interaction-service/shared/events"
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook request")
+)
+
+// SupportedEvents lists the events a webhook subscription can filter on.
+var SupportedEvents = []string{
+	events.POST_CREATED,
+	events.LIKE_ADDED,
+	events.COMMENT_CREATED,
+}
+
+type WebhookUseCase interface {
+	CreateSubscription(ctx context.Context, request *requests.WebhookSubscriptionRequest) (*responses.WebhookSubscriptionResponse, error)
+	FindAllSubscription(ctx context.Context) ([]*responses.WebhookSubscriptionResponse, error)
+	FindSubscription(ctx context.Context, subscriptionId string) (*responses.WebhookSubscriptionResponse, error)
+	UpdateSubscription(ctx context.Context, subscriptionId string, request *requests.WebhookSubscriptionRequest) (*responses.WebhookSubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, subscriptionId string) error
+	FindAllDelivery(ctx context.Context, subscriptionId string, limit int) ([]*responses.WebhookDeliveryResponse, error)
+	FindDelivery(ctx context.Context, deliveryId string) (*responses.WebhookDeliveryResponse, error)
+	ReplayDelivery(ctx context.Context, deliveryId string) (*responses.WebhookDeliveryResponse, error)
+}
+
+type WebhookRepository interface {
+	SaveSubscription(ctx context.Context, subscription *entities.WebhookSubscription) (*entities.WebhookSubscription, error)
+	FindSubscriptions(ctx context.Context, owner string) ([]*entities.WebhookSubscription, error)
+	FindSubscription(ctx context.Context, owner string, id string) (*entities.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, owner string, id string) error
+	FindActiveSubscriptions(ctx context.Context, eventType string) ([]*entities.WebhookSubscription, error)
+
+	EnqueueDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	FindDeliveries(ctx context.Context, subscriptionId string, limit int) ([]*entities.WebhookDelivery, error)
+	FindDelivery(ctx context.Context, owner string, id string) (*entities.WebhookDelivery, error)
+	ResetDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error
+}
+
+// WebhookSender performs the HTTP call for one delivery. It reports the
+// outcome in the returned attempt; an error is only returned when the
+// attempt itself could not be built.
+type WebhookSender interface {
+	Send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) (*entities.WebhookAttempt, error)
+}
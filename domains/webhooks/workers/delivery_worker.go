@@ -0,0 +1,142 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/webhooks"
+	"bootcamp-content-interaction-service/domains/webhooks/entities"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DeliveryWorker sends queued webhook deliveries. A failed delivery is retried
+// with exponential backoff until MaxAttempts, after which it is marked failed
+// and only comes back through a replay. Deliveries of one subscription are
+// not ordered.
+type DeliveryWorker struct {
+	repo   webhooks.WebhookRepository
+	sender webhooks.WebhookSender
+	conf   config.Webhook
+	logger util.Logger
+}
+
+func NewDeliveryWorker(repo webhooks.WebhookRepository, sender webhooks.WebhookSender, conf config.Webhook, logger util.Logger) *DeliveryWorker {
+	return &DeliveryWorker{
+		repo:   repo,
+		sender: sender,
+		conf:   conf.WithDefaults(),
+		logger: logger,
+	}
+}
+
+func (w *DeliveryWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.conf.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				if claimed := w.RunOnce(ctx); claimed < w.conf.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RunOnce claims and sends one batch, returning how many deliveries it
+// claimed.
+func (w *DeliveryWorker) RunOnce(ctx context.Context) int {
+	// The lease has to outlive every attempt in the batch, or a slow batch
+	// would be claimed a second time by another worker.
+	lease := w.conf.Timeout*time.Duration(w.conf.BatchSize/w.conf.Workers+1) + time.Minute
+	deliveries, err := w.repo.ClaimDue(ctx, w.conf.BatchSize, lease)
+	if err != nil {
+		w.logger.Error("Failed to claim webhook deliveries", zap.Error(err))
+		return 0
+	}
+
+	jobs := make(chan *entities.WebhookDelivery)
+	var wg sync.WaitGroup
+	for i := 0; i < w.conf.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				w.deliver(ctx, delivery)
+			}
+		}()
+	}
+	for _, delivery := range deliveries {
+		jobs <- delivery
+	}
+	close(jobs)
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+func (w *DeliveryWorker) deliver(ctx context.Context, delivery *entities.WebhookDelivery) {
+	var attempt *entities.WebhookAttempt
+	if delivery.Subscription.Active {
+		sendCtx, cancel := context.WithTimeout(ctx, w.conf.Timeout)
+		var err error
+		attempt, err = w.sender.Send(sendCtx, &delivery.Subscription, delivery)
+		cancel()
+		if err != nil {
+			attempt = &entities.WebhookAttempt{AttemptedAt: time.Now(), Error: err.Error()}
+		}
+	} else {
+		attempt = &entities.WebhookAttempt{AttemptedAt: time.Now(), Error: "subscription is inactive"}
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+
+	switch {
+	case attempt.Error == "":
+		now := time.Now()
+		delivery.Status = util.WEBHOOK_SUCCEEDED
+		delivery.DeliveredAt = &now
+	case !delivery.Subscription.Active || delivery.Attempts >= w.conf.MaxAttempts:
+		delivery.Status = util.WEBHOOK_FAILED
+	default:
+		delivery.NextAttemptAt = time.Now().Add(w.backoff(delivery.Attempts))
+	}
+
+	if err := w.repo.SaveAttempt(ctx, delivery, attempt); err != nil {
+		w.logger.Error("Failed to record webhook attempt",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if delivery.Status != util.WEBHOOK_SUCCEEDED {
+		w.logger.Warn("Webhook delivery failed",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.String("target_url", delivery.Subscription.TargetURL),
+			zap.Int("attempts", delivery.Attempts),
+			zap.String("status", delivery.Status),
+			zap.String("error", attempt.Error),
+		)
+	}
+}
+
+// backoff doubles BaseBackoff per attempt up to MaxBackoff and adds up to 20%
+// jitter so a receiver coming back up is not hit by every retry at once.
+func (w *DeliveryWorker) backoff(attempt int) time.Duration {
+	backoff := w.conf.BaseBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > w.conf.MaxBackoff {
+		backoff = w.conf.MaxBackoff
+	}
+	return backoff + time.Duration(rand.Int63n(int64(backoff)/5+1))
+}
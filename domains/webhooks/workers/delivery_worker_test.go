@@ -0,0 +1,302 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/domains/webhooks"
+	"bootcamp-content-interaction-service/domains/webhooks/entities"
+	"bootcamp-content-interaction-service/domains/webhooks/usecases"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeWebhookRepository keeps deliveries in memory and mimics the queue
+// semantics of the Postgres repository: ClaimDue leases due PENDING
+// deliveries and SaveAttempt appends to the delivery log.
+type fakeWebhookRepository struct {
+	webhooks.WebhookRepository
+
+	mu         sync.Mutex
+	deliveries map[uuid.UUID]*entities.WebhookDelivery
+}
+
+func newFakeWebhookRepository(deliveries ...*entities.WebhookDelivery) *fakeWebhookRepository {
+	repo := &fakeWebhookRepository{deliveries: make(map[uuid.UUID]*entities.WebhookDelivery)}
+	for _, delivery := range deliveries {
+		repo.deliveries[delivery.ID] = delivery
+	}
+	return repo
+}
+
+func (r *fakeWebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var claimed []*entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if delivery.Status != util.WEBHOOK_PENDING || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery.NextAttemptAt = now.Add(lease)
+		claim := *delivery
+		claim.Log = nil
+		claimed = append(claimed, &claim)
+	}
+	return claimed, nil
+}
+
+func (r *fakeWebhookRepository) SaveAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.deliveries[delivery.ID]
+	attempt.ID = uuid.New()
+	attempt.DeliveryID = delivery.ID
+	stored.Log = append(stored.Log, *attempt)
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.LastStatusCode = delivery.LastStatusCode
+	stored.LastError = delivery.LastError
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.DeliveredAt = delivery.DeliveredAt
+	return nil
+}
+
+func (r *fakeWebhookRepository) FindDelivery(ctx context.Context, owner string, id string) (*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.deliveries[uuid.MustParse(id)]
+	if !ok || stored.Subscription.Owner != owner {
+		return nil, webhooks.ErrWebhookNotFound
+	}
+	found := *stored
+	found.Log = append([]entities.WebhookAttempt(nil), stored.Log...)
+	return &found, nil
+}
+
+func (r *fakeWebhookRepository) ResetDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery.Status = util.WEBHOOK_PENDING
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+
+	stored := r.deliveries[delivery.ID]
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	return nil
+}
+
+func (r *fakeWebhookRepository) get(id uuid.UUID) entities.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.deliveries[id]
+}
+
+// makeDue lets a test skip a delivery's backoff.
+func (r *fakeWebhookRepository) makeDue(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[id].NextAttemptAt = time.Now()
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   string
+}
+
+// receiver is a local webhook endpoint that answers with the queued statuses,
+// then 200, and records every request.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	received []receivedWebhook
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	rc.received = append(rc.received, receivedWebhook{header: r.Header.Clone(), body: string(body)})
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	rc.mu.Unlock()
+
+	w.WriteHeader(status)
+	io.WriteString(w, http.StatusText(status))
+}
+
+func (rc *receiver) requests() []receivedWebhook {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return append([]receivedWebhook(nil), rc.received...)
+}
+
+func newTestDelivery(targetURL string) *entities.WebhookDelivery {
+	subscription := entities.WebhookSubscription{
+		ID:        uuid.New(),
+		Owner:     "analytics",
+		TargetURL: targetURL,
+		Secret:    "s3cret",
+		Active:    true,
+	}
+	return &entities.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		Subscription:   subscription,
+		EventID:        uuid.NewString(),
+		EventType:      "post.created",
+		Body:           `{"event":"post.created","data":{"post_id":"p1"}}`,
+		Status:         util.WEBHOOK_PENDING,
+		NextAttemptAt:  time.Now(),
+	}
+}
+
+func newTestDeliveryWorker(repo webhooks.WebhookRepository, client *http.Client) *DeliveryWorker {
+	return NewDeliveryWorker(repo, NewHTTPSender(client), config.Webhook{
+		Workers:     1,
+		BatchSize:   10,
+		MaxAttempts: 2,
+		BaseBackoff: time.Hour,
+		MaxBackoff:  2 * time.Hour,
+		Timeout:     2 * time.Second,
+	}, util.NewNopLogger())
+}
+
+func TestDeliveryWorkerSignsDeliveries(t *testing.T) {
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	delivery := newTestDelivery(server.URL)
+	repo := newFakeWebhookRepository(delivery)
+	worker := newTestDeliveryWorker(repo, server.Client())
+
+	if claimed := worker.RunOnce(context.Background()); claimed != 1 {
+		t.Fatalf("claimed %d deliveries, want 1", claimed)
+	}
+
+	requests := rc.requests()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	got := requests[0]
+	if got.body != delivery.Body {
+		t.Errorf("body = %q, want %q", got.body, delivery.Body)
+	}
+	if id := got.header.Get(HeaderWebhookID); id != delivery.ID.String() {
+		t.Errorf("%s = %q, want %q", HeaderWebhookID, id, delivery.ID)
+	}
+	if event := got.header.Get(HeaderWebhookEvent); event != delivery.EventType {
+		t.Errorf("%s = %q, want %q", HeaderWebhookEvent, event, delivery.EventType)
+	}
+
+	timestamp := got.header.Get(HeaderWebhookTimestamp)
+	mac := hmac.New(sha256.New, []byte(delivery.Subscription.Secret))
+	mac.Write([]byte(timestamp + "." + delivery.Body))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if signature := got.header.Get(HeaderWebhookSignature); !hmac.Equal([]byte(signature), []byte(want)) {
+		t.Errorf("%s = %q, want %q", HeaderWebhookSignature, signature, want)
+	}
+
+	stored := repo.get(delivery.ID)
+	if stored.Status != util.WEBHOOK_SUCCEEDED || stored.DeliveredAt == nil {
+		t.Errorf("status = %s, delivered at %v, want SUCCEEDED with a delivery time", stored.Status, stored.DeliveredAt)
+	}
+	if len(stored.Log) != 1 || stored.Log[0].StatusCode != http.StatusOK || stored.Log[0].Error != "" {
+		t.Errorf("log = %+v, want one successful attempt", stored.Log)
+	}
+}
+
+func TestDeliveryWorkerBacksOffFailsAndReplays(t *testing.T) {
+	rc := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	delivery := newTestDelivery(server.URL)
+	repo := newFakeWebhookRepository(delivery)
+	worker := newTestDeliveryWorker(repo, server.Client())
+	ctx := context.Background()
+
+	before := time.Now()
+	worker.RunOnce(ctx)
+
+	stored := repo.get(delivery.ID)
+	if stored.Status != util.WEBHOOK_PENDING || stored.Attempts != 1 || stored.LastStatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("after a 503: status %s, attempts %d, last status %d, want PENDING, 1, 503", stored.Status, stored.Attempts, stored.LastStatusCode)
+	}
+	// One hour of base backoff plus at most 20% jitter.
+	if wait := stored.NextAttemptAt.Sub(before); wait < time.Hour || wait > 73*time.Minute {
+		t.Errorf("next attempt in %s, want between 1h and 1h12m", wait)
+	}
+	if claimed := worker.RunOnce(ctx); claimed != 0 {
+		t.Fatalf("claimed %d deliveries during backoff, want 0", claimed)
+	}
+
+	repo.makeDue(delivery.ID)
+	worker.RunOnce(ctx)
+
+	stored = repo.get(delivery.ID)
+	if stored.Status != util.WEBHOOK_FAILED || stored.Attempts != 2 {
+		t.Fatalf("after max attempts: status %s, attempts %d, want FAILED, 2", stored.Status, stored.Attempts)
+	}
+	if len(stored.Log) != 2 {
+		t.Fatalf("log has %d attempts, want 2", len(stored.Log))
+	}
+	for i, status := range []int{http.StatusServiceUnavailable, http.StatusInternalServerError} {
+		if stored.Log[i].StatusCode != status || stored.Log[i].Error == "" || stored.Log[i].ResponseBody != http.StatusText(status) {
+			t.Errorf("log[%d] = %+v, want a failed attempt with status %d", i, stored.Log[i], status)
+		}
+	}
+
+	useCase := usecases.NewWebhookUseCase(repo)
+	serviceCtx := context.WithValue(ctx, "service", delivery.Subscription.Owner)
+	replayed, err := useCase.ReplayDelivery(serviceCtx, delivery.ID.String())
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replayed.Status != util.WEBHOOK_PENDING || replayed.Attempts != 0 {
+		t.Errorf("replayed status %s, attempts %d, want PENDING, 0", replayed.Status, replayed.Attempts)
+	}
+
+	if claimed := worker.RunOnce(ctx); claimed != 1 {
+		t.Fatalf("claimed %d deliveries after replay, want 1", claimed)
+	}
+
+	found, err := useCase.FindDelivery(serviceCtx, delivery.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Status != util.WEBHOOK_SUCCEEDED || len(found.Log) != 3 || found.Log[2].StatusCode != http.StatusOK {
+		t.Errorf("after replay: status %s, log %+v, want SUCCEEDED with the earlier attempts kept", found.Status, found.Log)
+	}
+
+	requests := rc.requests()
+	if len(requests) != 3 {
+		t.Fatalf("receiver got %d requests, want 3", len(requests))
+	}
+	for i, got := range requests {
+		if got.body != delivery.Body || got.header.Get(HeaderWebhookID) != delivery.ID.String() {
+			t.Errorf("request %d differs from the original delivery", i)
+		}
+	}
+}
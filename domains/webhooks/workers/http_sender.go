@@ -0,0 +1,80 @@
+package workers
+
+import (
+	"bootcamp-content-interaction-service/domains/webhooks"
+	"bootcamp-content-interaction-service/domains/webhooks/entities"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+
+	maxResponseBody = 1024
+)
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender sends deliveries with client, which lets callers point the
+// worker at an httptest server or tune transport timeouts.
+func NewHTTPSender(client *http.Client) webhooks.WebhookSender {
+	return &HTTPSender{client: client}
+}
+
+// SignWebhook returns the X-Webhook-Signature value for a body: "sha256="
+// followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// subscription secret. Receivers recompute it to verify a delivery.
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *HTTPSender) Send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) (*entities.WebhookAttempt, error) {
+	body := []byte(delivery.Body)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.TargetURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "content-interaction-service-webhooks")
+	req.Header.Set(HeaderWebhookID, delivery.ID.String())
+	req.Header.Set(HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(HeaderWebhookSignature, SignWebhook(subscription.Secret, timestamp, body))
+
+	started := time.Now()
+	attempt := &entities.WebhookAttempt{AttemptedAt: started}
+
+	resp, err := s.client.Do(req)
+	attempt.DurationMs = time.Since(started).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt, nil
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	attempt.StatusCode = resp.StatusCode
+	attempt.ResponseBody = string(respBody)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = "unexpected status " + resp.Status
+	}
+
+	return attempt, nil
+}
@@ -0,0 +1,22 @@
+package infrastructures
+
+import "context"
+
+// AltTextGenerator describes post media for screen readers. It is only asked
+// about items their author left without alt text, and an empty description
+// leaves the item as it is.
+type AltTextGenerator interface {
+	GenerateAltText(ctx context.Context, mediaType string, renditions map[string]string) (string, error)
+}
+
+// NoopAltTextGenerator never describes anything; it is used until a real
+// generator is configured.
+type NoopAltTextGenerator struct{}
+
+func NewNoopAltTextGenerator() AltTextGenerator {
+	return NoopAltTextGenerator{}
+}
+
+func (NoopAltTextGenerator) GenerateAltText(ctx context.Context, mediaType string, renditions map[string]string) (string, error) {
+	return "", nil
+}
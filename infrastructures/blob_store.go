@@ -0,0 +1,78 @@
+package infrastructures
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrInvalidBlobKey = errors.New("invalid blob key")
+)
+
+// Blob is an object read back from a BlobStore. Body seeks so it can serve
+// range requests; callers close it.
+type Blob struct {
+	Body        io.ReadSeekCloser
+	ContentType string
+	Size        int64
+	ETag        string
+	ModTime     time.Time
+}
+
+// BlobStore keeps uploaded media under slash-separated object keys such as
+// "post/<uuid>.jpg". Deleting a missing key is not an error. Clients never
+// reach a store directly; they fetch objects through the media endpoint
+// with URLs from URLSigner.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (*Blob, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore picks the store named by storage.driver: "s3" talks to an
+// S3-compatible service, "memory" keeps objects in process for tests, and
+// anything else writes under storage.local_dir.
+func NewBlobStore(conf *config.Storage, logger util.Logger) (BlobStore, error) {
+	var storage config.Storage
+	if conf != nil {
+		storage = *conf
+	}
+	storage = storage.WithDefaults()
+
+	switch storage.Driver {
+	case "s3":
+		return NewS3BlobStore(&storage.S3)
+	case "memory":
+		return NewMemoryBlobStore(), nil
+	default:
+		logger.Info("Using local blob store", zap.String("dir", storage.LocalDir))
+		return NewLocalBlobStore(storage.LocalDir), nil
+	}
+}
+
+// ValidateBlobKey rejects keys that are empty, absolute or climb out of the
+// store with "..".
+func ValidateBlobKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("%w: %q", ErrInvalidBlobKey, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidBlobKey, key)
+		}
+	}
+	return nil
+}
+
+func joinURL(base string, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
@@ -0,0 +1,91 @@
+package infrastructures
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryBlob struct {
+	data        []byte
+	contentType string
+	modTime     time.Time
+}
+
+// MemoryBlobStore keeps objects in process. It stands in for the real stores
+// in tests and local runs.
+type MemoryBlobStore struct {
+	mu    sync.Mutex
+	blobs map[string]memoryBlob
+}
+
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{
+		blobs: make(map[string]memoryBlob),
+	}
+}
+
+func (s *MemoryBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := ValidateBlobKey(key); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = memoryBlob{data: data, contentType: contentType, modTime: time.Now()}
+	return nil
+}
+
+func (s *MemoryBlobStore) Get(ctx context.Context, key string) (*Blob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blob, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	sum := sha256.Sum256(blob.data)
+	return &Blob{
+		Body:        nopSeekCloser{bytes.NewReader(blob.data)},
+		ContentType: blob.contentType,
+		Size:        int64(len(blob.data)),
+		ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+		ModTime:     blob.modTime,
+	}, nil
+}
+
+func (s *MemoryBlobStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+// Keys lists the stored keys in order.
+func (s *MemoryBlobStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.blobs))
+	for key := range s.blobs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error { return nil }
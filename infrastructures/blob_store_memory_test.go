@@ -0,0 +1,112 @@
+package infrastructures
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMemoryBlobStorePutGetDelete(t *testing.T) {
+	store := NewMemoryBlobStore()
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "post/a.jpg", strings.NewReader("hello world"), -1, "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, "avatar/b.png", strings.NewReader("png"), 3, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	if keys := store.Keys(); !reflect.DeepEqual(keys, []string{"avatar/b.png", "post/a.jpg"}) {
+		t.Errorf("keys = %v, want both keys in order", keys)
+	}
+
+	blob, err := store.Get(ctx, "post/a.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(blob.Body)
+	blob.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello world" || blob.Size != 11 || blob.ContentType != "image/jpeg" {
+		t.Errorf("got %q, size %d, type %q, want the stored object", data, blob.Size, blob.ContentType)
+	}
+	if blob.ETag == "" || blob.ModTime.IsZero() {
+		t.Errorf("etag %q, mod time %v, want both set", blob.ETag, blob.ModTime)
+	}
+
+	again, err := store.Get(ctx, "post/a.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ETag != blob.ETag {
+		t.Errorf("etag changed between reads: %q, %q", blob.ETag, again.ETag)
+	}
+
+	if err := store.Delete(ctx, "post/a.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "post/a.jpg"); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("get after delete: err = %v, want ErrBlobNotFound", err)
+	}
+	if err := store.Delete(ctx, "post/a.jpg"); err != nil {
+		t.Errorf("deleting a missing key: err = %v, want nil", err)
+	}
+}
+
+func TestMemoryBlobStoreRejectsInvalidKeys(t *testing.T) {
+	store := NewMemoryBlobStore()
+
+	for _, key := range []string{"", "/post/a.jpg", "post/../a.jpg", "post//a.jpg", `post\a.jpg`} {
+		err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "")
+		if !errors.Is(err, ErrInvalidBlobKey) {
+			t.Errorf("Put(%q): err = %v, want ErrInvalidBlobKey", key, err)
+		}
+	}
+	if keys := store.Keys(); len(keys) != 0 {
+		t.Errorf("keys = %v, want none", keys)
+	}
+}
+
+func TestMemoryBlobStoreServesRanges(t *testing.T) {
+	store := NewMemoryBlobStore()
+	if err := store.Put(context.Background(), "post/v.mp4", strings.NewReader("0123456789"), 10, "video/mp4"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		rangeHeader string
+		status      int
+		body        string
+	}{
+		{"bytes=2-5", http.StatusPartialContent, "2345"},
+		{"bytes=7-", http.StatusPartialContent, "789"},
+		{"bytes=-3", http.StatusPartialContent, "789"},
+		{"bytes=20-", http.StatusRequestedRangeNotSatisfiable, ""},
+	} {
+		blob, err := store.Get(context.Background(), "post/v.mp4")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/media/post/v.mp4", nil)
+		req.Header.Set("Range", tc.rangeHeader)
+		rec := httptest.NewRecorder()
+		http.ServeContent(rec, req, "", blob.ModTime, blob.Body)
+		blob.Body.Close()
+
+		if rec.Code != tc.status {
+			t.Errorf("%s: status %d, want %d", tc.rangeHeader, rec.Code, tc.status)
+			continue
+		}
+		if tc.status == http.StatusPartialContent && rec.Body.String() != tc.body {
+			t.Errorf("%s: body %q, want %q", tc.rangeHeader, rec.Body.String(), tc.body)
+		}
+	}
+}
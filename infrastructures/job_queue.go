@@ -0,0 +1,352 @@
+package infrastructures
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+type Job struct {
+	ID      string
+	Type    string
+	Payload []byte
+	Attempt int
+}
+
+type JobHandler func(ctx context.Context, job *Job) error
+
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	Consume(ctx context.Context, handler JobHandler)
+}
+
+type retryEntry struct {
+	Type    string `json:"type"`
+	Payload string `json:"payload"`
+	Attempt int    `json:"attempt"`
+	Nonce   int64  `json:"nonce"`
+}
+
+// RedisStreamQueue is a durable job queue on top of a Redis stream and a
+// consumer group. Failed jobs wait in a sorted set until their backoff expires
+// and are then re-added to the stream; jobs that exhaust their attempts are
+// moved to a dead-letter stream. Messages left pending by a crashed consumer
+// are reclaimed after ClaimIdle.
+type RedisStreamQueue struct {
+	client     *redis.Client
+	stream     string
+	group      string
+	retryKey   string
+	deadLetter string
+	consumer   string
+	conf       config.Queue
+	logger     util.Logger
+}
+
+func NewRedisStreamQueue(client *redis.Client, name string, conf config.Queue, logger util.Logger) JobQueue {
+	hostname, _ := os.Hostname()
+
+	return &RedisStreamQueue{
+		client:     client,
+		stream:     "jobs:" + name,
+		group:      name + "-workers",
+		retryKey:   "jobs:" + name + ":retry",
+		deadLetter: "jobs:" + name + ":dead",
+		consumer:   fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		conf:       conf.WithDefaults(),
+		logger:     logger,
+	}
+}
+
+func (q *RedisStreamQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return q.add(ctx, jobType, string(payloadJSON), 0)
+}
+
+func (q *RedisStreamQueue) add(ctx context.Context, jobType string, payload string, attempt int) error {
+	return q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.stream,
+		MaxLen: q.conf.MaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":    jobType,
+			"payload": payload,
+			"attempt": attempt,
+		},
+	}).Err()
+}
+
+func (q *RedisStreamQueue) Consume(ctx context.Context, handler JobHandler) {
+	err := q.client.XGroupCreateMkStream(ctx, q.stream, q.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		q.logger.Error("Failed to create consumer group",
+			zap.String("stream", q.stream),
+			zap.Error(err),
+		)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < q.conf.Workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			q.work(ctx, fmt.Sprintf("%s-%d", q.consumer, worker), handler)
+		}(i)
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		q.promoteRetries(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		q.reclaim(ctx, handler)
+	}()
+
+	wg.Wait()
+}
+
+func (q *RedisStreamQueue) work(ctx context.Context, consumer string, handler JobHandler) {
+	for ctx.Err() == nil {
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    q.group,
+			Consumer: consumer,
+			Streams:  []string{q.stream, ">"},
+			Count:    int64(q.conf.BatchSize),
+			Block:    5 * time.Second,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				q.logger.Warn("Redis XREADGROUP failed",
+					zap.String("stream", q.stream),
+					zap.Error(err),
+				)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				q.process(ctx, msg, handler)
+			}
+		}
+	}
+}
+
+func (q *RedisStreamQueue) process(ctx context.Context, msg redis.XMessage, handler JobHandler) {
+	job := &Job{
+		ID:      msg.ID,
+		Type:    fmt.Sprint(msg.Values["type"]),
+		Payload: []byte(fmt.Sprint(msg.Values["payload"])),
+	}
+	job.Attempt, _ = strconv.Atoi(fmt.Sprint(msg.Values["attempt"]))
+
+	if err := handler(ctx, job); err != nil {
+		// Until the retry or dead letter is stored the message is the only
+		// copy of the job, so it stays pending for reclaim to redeliver.
+		if failErr := q.fail(ctx, job, err); failErr != nil {
+			q.logger.Error("Failed to reschedule job, leaving it pending",
+				zap.String("stream", q.stream),
+				zap.String("job_id", msg.ID),
+				zap.Error(failErr),
+			)
+		}
+		return
+	}
+
+	if err := q.client.XAck(ctx, q.stream, q.group, msg.ID).Err(); err != nil {
+		q.logger.Warn("Redis XACK failed",
+			zap.String("stream", q.stream),
+			zap.String("job_id", msg.ID),
+			zap.Error(err),
+		)
+		return
+	}
+	_ = q.client.XDel(ctx, q.stream, msg.ID).Err()
+}
+
+// fail schedules a retry of job, or moves it to the dead-letter stream once
+// it has used up its attempts. The message is acknowledged in the same
+// MULTI, so it is either rescheduled and gone from the stream or left
+// pending untouched; reclaim never hands out a job that is also waiting to
+// be retried.
+func (q *RedisStreamQueue) fail(ctx context.Context, job *Job, jobErr error) error {
+	attempt := job.Attempt + 1
+
+	if attempt >= q.conf.MaxAttempts {
+		q.logger.Error("Job exhausted its attempts, moving to dead letter",
+			zap.String("stream", q.stream),
+			zap.String("job_id", job.ID),
+			zap.String("job_type", job.Type),
+			zap.Int("attempt", attempt),
+			zap.Error(jobErr),
+		)
+		return q.ackWith(ctx, job.ID, func(pipe redis.Pipeliner) {
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: q.deadLetter,
+				Values: map[string]interface{}{
+					"type":      job.Type,
+					"payload":   string(job.Payload),
+					"attempt":   attempt,
+					"error":     jobErr.Error(),
+					"failed_at": time.Now().Format(time.RFC3339),
+				},
+			})
+		})
+	}
+
+	backoff := q.backoff(attempt)
+	q.logger.Warn("Job failed, scheduling retry",
+		zap.String("stream", q.stream),
+		zap.String("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.Int("attempt", attempt),
+		zap.Duration("backoff", backoff),
+		zap.Error(jobErr),
+	)
+
+	entryJSON, err := json.Marshal(retryEntry{
+		Type:    job.Type,
+		Payload: string(job.Payload),
+		Attempt: attempt,
+		Nonce:   time.Now().UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+	return q.ackWith(ctx, job.ID, func(pipe redis.Pipeliner) {
+		pipe.ZAdd(ctx, q.retryKey, redis.Z{
+			Score:  float64(time.Now().Add(backoff).UnixMilli()),
+			Member: entryJSON,
+		})
+	})
+}
+
+// ackWith runs queue in one MULTI with the XACK and XDEL of message id.
+func (q *RedisStreamQueue) ackWith(ctx context.Context, id string, queue func(pipe redis.Pipeliner)) error {
+	_, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		queue(pipe)
+		pipe.XAck(ctx, q.stream, q.group, id)
+		pipe.XDel(ctx, q.stream, id)
+		return nil
+	})
+	return err
+}
+
+// backoff doubles BaseBackoff per attempt up to MaxBackoff and adds up to 20%
+// jitter so retries from a burst of failures do not land together.
+func (q *RedisStreamQueue) backoff(attempt int) time.Duration {
+	backoff := q.conf.BaseBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > q.conf.MaxBackoff {
+		backoff = q.conf.MaxBackoff
+	}
+	return backoff + time.Duration(rand.Int63n(int64(backoff)/5+1))
+}
+
+func (q *RedisStreamQueue) promoteRetries(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		due, err := q.client.ZRangeByScore(ctx, q.retryKey, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+			Count: 100,
+		}).Result()
+		if err != nil {
+			continue
+		}
+
+		for _, member := range due {
+			// Only the instance that wins the ZREM re-enqueues the job.
+			removed, err := q.client.ZRem(ctx, q.retryKey, member).Result()
+			if err != nil || removed == 0 {
+				continue
+			}
+
+			var entry retryEntry
+			if err := json.Unmarshal([]byte(member), &entry); err != nil {
+				continue
+			}
+			if err := q.add(ctx, entry.Type, entry.Payload, entry.Attempt); err != nil {
+				q.logger.Warn("Failed to re-enqueue retried job",
+					zap.String("stream", q.stream),
+					zap.Error(err),
+				)
+				_ = q.client.ZAdd(ctx, q.retryKey, redis.Z{
+					Score:  float64(time.Now().Add(q.conf.BaseBackoff).UnixMilli()),
+					Member: member,
+				}).Err()
+			}
+		}
+	}
+}
+
+func (q *RedisStreamQueue) reclaim(ctx context.Context, handler JobHandler) {
+	ticker := time.NewTicker(q.conf.ClaimIdle)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		start := "0-0"
+		for {
+			msgs, next, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+				Stream:   q.stream,
+				Group:    q.group,
+				Consumer: q.consumer + "-reclaim",
+				MinIdle:  q.conf.ClaimIdle,
+				Start:    start,
+				Count:    int64(q.conf.BatchSize),
+			}).Result()
+			if err != nil {
+				break
+			}
+
+			for _, msg := range msgs {
+				q.logger.Info("Reclaimed stalled job",
+					zap.String("stream", q.stream),
+					zap.String("job_id", msg.ID),
+				)
+				q.process(ctx, msg, handler)
+			}
+
+			if next == "0-0" || len(msgs) == 0 {
+				break
+			}
+			start = next
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package infrastructures
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/shared/util"
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type MailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg *MailMessage) error
+}
+
+// NewMailer picks the mailer named by mail.driver: "smtp" sends for real,
+// anything else writes messages to disk or the log for local development.
+func NewMailer(conf *config.Mail, logger util.Logger) Mailer {
+	if conf == nil {
+		return NewLogMailer("", "no-reply@localhost", logger)
+	}
+	if conf.Driver == "smtp" {
+		return NewSMTPMailer(conf)
+	}
+	return NewLogMailer(conf.OutputDir, conf.From, logger)
+}
+
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPMailer(conf *config.Mail) Mailer {
+	var auth smtp.Auth
+	if conf.Username != "" {
+		auth = smtp.PlainAuth("", conf.Username, conf.Password, conf.Host)
+	}
+
+	return &SMTPMailer{
+		addr: conf.Host + ":" + strconv.Itoa(conf.Port),
+		auth: auth,
+		from: conf.From,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg *MailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	body, err := BuildMailMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(m.addr, m.auth, from.Address, []string{to.Address}, body)
+}
+
+// LogMailer never sends anything. It writes each message as an .eml file
+// under dir when one is set, and logs the recipient and subject either way.
+type LogMailer struct {
+	dir    string
+	from   string
+	logger util.Logger
+}
+
+func NewLogMailer(dir string, from string, logger util.Logger) Mailer {
+	return &LogMailer{
+		dir:    dir,
+		from:   from,
+		logger: logger,
+	}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg *MailMessage) error {
+	fields := []zap.Field{
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+	}
+
+	if m.dir != "" {
+		body, err := BuildMailMessage(m.from, msg)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(m.dir, 0o755); err != nil {
+			return err
+		}
+
+		path := filepath.Join(m.dir, time.Now().UTC().Format("20060102T150405")+"-"+uuid.NewString()+".eml")
+		if err := os.WriteFile(path, body, 0o644); err != nil {
+			return err
+		}
+		fields = append(fields, zap.String("path", path))
+	} else {
+		fields = append(fields, zap.String("text", msg.Text))
+	}
+
+	m.logger.Info("Mail not sent, written by log mailer", fields...)
+	return nil
+}
+
+// BuildMailMessage renders msg as a multipart/alternative RFC 5322 message
+// with a plain-text and an HTML part.
+func BuildMailMessage(from string, msg *MailMessage) ([]byte, error) {
+	var parts bytes.Buffer
+	writer := multipart.NewWriter(&parts)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := []string{
+		"From: " + from,
+		"To: " + msg.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + writer.Boundary(),
+	}
+	message.WriteString(strings.Join(headers, "\r\n"))
+	message.WriteString("\r\n\r\n")
+	message.Write(parts.Bytes())
+
+	return message.Bytes(), nil
+}
@@ -0,0 +1,175 @@
+package infrastructures
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/shared/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrPosterUnavailable is returned by probes that cannot render frames.
+var ErrPosterUnavailable = errors.New("poster frame extraction unavailable")
+
+// ProbeResult describes a video file. Codecs use ffprobe's names, such as
+// "h264", "hevc" and "aac"; AudioCodec is empty for a silent video. Width
+// and Height are the display size, with any rotation already applied.
+type ProbeResult struct {
+	VideoCodec string
+	AudioCodec string
+	Width      int
+	Height     int
+	Duration   time.Duration
+}
+
+// MediaProbe reads the metadata of a video file and renders single frames
+// from it as JPEG.
+type MediaProbe interface {
+	Probe(ctx context.Context, path string) (*ProbeResult, error)
+	PosterFrame(ctx context.Context, path string, at time.Duration) ([]byte, error)
+}
+
+// NewMediaProbe uses ffprobe, and ffmpeg for poster frames, when they can be
+// found; otherwise it falls back to reading MP4 and QuickTime metadata in Go,
+// which cannot produce poster frames.
+func NewMediaProbe(conf config.Media, logger util.Logger) MediaProbe {
+	conf = conf.WithDefaults()
+
+	ffprobe, err := exec.LookPath(conf.FFprobePath)
+	if err != nil {
+		logger.Info("ffprobe not found, reading video metadata natively", zap.String("ffprobe", conf.FFprobePath))
+		return NewNativeMediaProbe()
+	}
+
+	ffmpeg, err := exec.LookPath(conf.FFmpegPath)
+	if err != nil {
+		logger.Info("ffmpeg not found, videos are stored without poster frames", zap.String("ffmpeg", conf.FFmpegPath))
+		ffmpeg = ""
+	}
+	return NewFFmpegMediaProbe(ffprobe, ffmpeg, conf.ProbeTimeout)
+}
+
+type FFmpegMediaProbe struct {
+	ffprobe string
+	ffmpeg  string
+	timeout time.Duration
+}
+
+// NewFFmpegMediaProbe runs the given binaries. An empty ffmpeg path leaves
+// the probe without poster frames.
+func NewFFmpegMediaProbe(ffprobe string, ffmpeg string, timeout time.Duration) MediaProbe {
+	return &FFmpegMediaProbe{
+		ffprobe: ffprobe,
+		ffmpeg:  ffmpeg,
+		timeout: timeout,
+	}
+}
+
+type ffprobeOutput struct {
+	Streams []struct {
+		CodecType string            `json:"codec_type"`
+		CodecName string            `json:"codec_name"`
+		Width     int               `json:"width"`
+		Height    int               `json:"height"`
+		Tags      map[string]string `json:"tags"`
+		SideData  []struct {
+			Rotation float64 `json:"rotation"`
+		} `json:"side_data_list"`
+	} `json:"streams"`
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+}
+
+func (f *FFmpegMediaProbe) Probe(ctx context.Context, path string) (*ProbeResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	output, err := f.run(ctx, f.ffprobe,
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		path,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var probed ffprobeOutput
+	if err := json.Unmarshal(output, &probed); err != nil {
+		return nil, fmt.Errorf("unreadable ffprobe output: %w", err)
+	}
+
+	result := &ProbeResult{}
+	for _, stream := range probed.Streams {
+		switch stream.CodecType {
+		case "video":
+			if result.VideoCodec != "" {
+				continue
+			}
+			result.VideoCodec = stream.CodecName
+			result.Width, result.Height = stream.Width, stream.Height
+
+			rotation, _ := strconv.ParseFloat(stream.Tags["rotate"], 64)
+			for _, side := range stream.SideData {
+				if side.Rotation != 0 {
+					rotation = side.Rotation
+				}
+			}
+			if int(rotation)%180 != 0 {
+				result.Width, result.Height = result.Height, result.Width
+			}
+		case "audio":
+			if result.AudioCodec == "" {
+				result.AudioCodec = stream.CodecName
+			}
+		}
+	}
+
+	seconds, err := strconv.ParseFloat(probed.Format.Duration, 64)
+	if err == nil {
+		result.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	return result, nil
+}
+
+// PosterFrame renders the frame at the given offset. ffmpeg applies the
+// video's rotation, so the frame comes out upright.
+func (f *FFmpegMediaProbe) PosterFrame(ctx context.Context, path string, at time.Duration) ([]byte, error) {
+	if f.ffmpeg == "" {
+		return nil, ErrPosterUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	return f.run(ctx, f.ffmpeg,
+		"-v", "error",
+		"-ss", strconv.FormatFloat(at.Seconds(), 'f', 3, 64),
+		"-i", path,
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-vcodec", "mjpeg",
+		"-",
+	)
+}
+
+func (f *FFmpegMediaProbe) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", name, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,220 @@
+package infrastructures
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+var errMalformedMP4 = errors.New("malformed MP4 box structure")
+
+// mp4Codecs maps sample entry formats to the codec names ffprobe reports.
+var mp4Codecs = map[string]string{
+	"avc1": "h264",
+	"avc3": "h264",
+	"hvc1": "hevc",
+	"hev1": "hevc",
+	"mp4a": "aac",
+}
+
+// NativeMediaProbe reads MP4 and QuickTime metadata from the box structure
+// without external tools. It cannot decode video, so it has no poster
+// frames.
+type NativeMediaProbe struct{}
+
+func NewNativeMediaProbe() MediaProbe {
+	return NativeMediaProbe{}
+}
+
+func (NativeMediaProbe) Probe(ctx context.Context, path string) (*ProbeResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	movie := &mp4Movie{file: file}
+	if err := movie.walk(0, info.Size(), nil, 0); err != nil {
+		return nil, err
+	}
+	if !movie.found {
+		return nil, fmt.Errorf("%w: no movie header", errMalformedMP4)
+	}
+	if movie.timescale == 0 {
+		return nil, fmt.Errorf("%w: movie has no timescale", errMalformedMP4)
+	}
+
+	result := &ProbeResult{
+		Duration: time.Duration(float64(movie.duration) / float64(movie.timescale) * float64(time.Second)),
+	}
+	for _, track := range movie.tracks {
+		codec := mp4Codecs[track.format]
+		if codec == "" {
+			codec = track.format
+		}
+
+		switch track.handler {
+		case "vide":
+			if result.VideoCodec != "" {
+				continue
+			}
+			result.VideoCodec = codec
+			result.Width, result.Height = track.width, track.height
+			if track.rotated {
+				result.Width, result.Height = result.Height, result.Width
+			}
+		case "soun":
+			if result.AudioCodec == "" {
+				result.AudioCodec = codec
+			}
+		}
+	}
+	return result, nil
+}
+
+func (NativeMediaProbe) PosterFrame(ctx context.Context, path string, at time.Duration) ([]byte, error) {
+	return nil, ErrPosterUnavailable
+}
+
+type mp4Track struct {
+	handler string
+	format  string
+	width   int
+	height  int
+	rotated bool
+}
+
+type mp4Movie struct {
+	file      io.ReaderAt
+	found     bool
+	timescale uint32
+	duration  uint64
+	tracks    []*mp4Track
+}
+
+// walk visits the boxes between start and end, descending into the
+// containers that lead to the movie and track headers.
+func (m *mp4Movie) walk(start int64, end int64, track *mp4Track, depth int) error {
+	if depth > 8 {
+		return fmt.Errorf("%w: boxes nested too deep", errMalformedMP4)
+	}
+
+	for offset := start; offset+8 <= end; {
+		header, err := m.read(offset, 16, end)
+		if err != nil {
+			return err
+		}
+		size, kind, headerLen := int64(binary.BigEndian.Uint32(header[0:4])), string(header[4:8]), int64(8)
+		switch size {
+		case 0:
+			size = end - offset
+		case 1:
+			if len(header) < 16 {
+				return errMalformedMP4
+			}
+			size, headerLen = int64(binary.BigEndian.Uint64(header[8:16])), 16
+		}
+		if size < headerLen || size > end-offset {
+			return fmt.Errorf("%w: %q box overruns its parent", errMalformedMP4, kind)
+		}
+		body, bodyEnd := offset+headerLen, offset+size
+
+		switch {
+		case kind == "moov":
+			m.found = true
+			err = m.walk(body, bodyEnd, nil, depth+1)
+		case kind == "trak":
+			next := &mp4Track{}
+			m.tracks = append(m.tracks, next)
+			err = m.walk(body, bodyEnd, next, depth+1)
+		case track != nil && (kind == "mdia" || kind == "minf" || kind == "stbl"):
+			err = m.walk(body, bodyEnd, track, depth+1)
+		case kind == "mvhd":
+			err = m.readMovieHeader(body, bodyEnd)
+		case track != nil && kind == "tkhd":
+			err = m.readTrackHeader(track, body, bodyEnd)
+		case track != nil && kind == "hdlr":
+			var payload []byte
+			if payload, err = m.read(body, 12, bodyEnd); err == nil && len(payload) == 12 {
+				track.handler = string(payload[8:12])
+			}
+		case track != nil && kind == "stsd":
+			var payload []byte
+			if payload, err = m.read(body, 16, bodyEnd); err == nil && len(payload) == 16 {
+				track.format = string(payload[12:16])
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		offset = bodyEnd
+	}
+	return nil
+}
+
+func (m *mp4Movie) readMovieHeader(start int64, end int64) error {
+	payload, err := m.read(start, 32, end)
+	if err != nil {
+		return err
+	}
+
+	if len(payload) >= 32 && payload[0] == 1 {
+		m.timescale = binary.BigEndian.Uint32(payload[20:24])
+		m.duration = binary.BigEndian.Uint64(payload[24:32])
+		return nil
+	}
+	if len(payload) < 20 {
+		return fmt.Errorf("%w: short movie header", errMalformedMP4)
+	}
+	m.timescale = binary.BigEndian.Uint32(payload[12:16])
+	m.duration = uint64(binary.BigEndian.Uint32(payload[16:20]))
+	return nil
+}
+
+// readTrackHeader takes the display size, a 16.16 fixed point pair, and
+// whether the transformation matrix turns the picture by 90 or 270 degrees.
+func (m *mp4Movie) readTrackHeader(track *mp4Track, start int64, end int64) error {
+	payload, err := m.read(start, 96, end)
+	if err != nil {
+		return err
+	}
+
+	base := 24
+	if len(payload) > 0 && payload[0] == 1 {
+		base = 36
+	}
+	if len(payload) < base+60 {
+		return fmt.Errorf("%w: short track header", errMalformedMP4)
+	}
+
+	matrix := payload[base+16 : base+52]
+	a := int32(binary.BigEndian.Uint32(matrix[0:4]))
+	d := int32(binary.BigEndian.Uint32(matrix[16:20]))
+	track.rotated = a == 0 && d == 0
+	track.width = int(binary.BigEndian.Uint32(payload[base+52:base+56]) >> 16)
+	track.height = int(binary.BigEndian.Uint32(payload[base+56:base+60]) >> 16)
+	return nil
+}
+
+// read returns up to n bytes at offset without reading past end.
+func (m *mp4Movie) read(offset int64, n int64, end int64) ([]byte, error) {
+	if n > end-offset {
+		n = end - offset
+	}
+	buf := make([]byte, n)
+	read, err := m.file.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return buf[:read], nil
+}
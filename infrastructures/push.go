@@ -0,0 +1,250 @@
+package infrastructures
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bootcamp-content-interaction-service/shared/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrInvalidPushToken is wrapped by providers when the platform reports the
+// token as unregistered or malformed. Callers delete such tokens.
+var ErrInvalidPushToken = errors.New("invalid push token")
+
+type PushMessage struct {
+	Title       string
+	Body        string
+	Data        map[string]string
+	CollapseKey string
+}
+
+type PushTarget struct {
+	Platform string
+	Token    string
+}
+
+type PushProvider interface {
+	Send(ctx context.Context, target *PushTarget, msg *PushMessage) error
+}
+
+type fcmMessage struct {
+	Message fcmBody `json:"message"`
+}
+
+type fcmBody struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *fcmAndroid       `json:"android,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmAndroid struct {
+	Priority    string `json:"priority"`
+	CollapseKey string `json:"collapse_key,omitempty"`
+}
+
+// BuildFCMPayload renders msg as an FCM HTTP v1 send request body.
+func BuildFCMPayload(token string, msg *PushMessage) ([]byte, error) {
+	return json.Marshal(fcmMessage{
+		Message: fcmBody{
+			Token:        token,
+			Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+			Data:         msg.Data,
+			Android: &fcmAndroid{
+				Priority:    "HIGH",
+				CollapseKey: msg.CollapseKey,
+			},
+		},
+	})
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type apnsAps struct {
+	Alert    apnsAlert `json:"alert"`
+	Sound    string    `json:"sound"`
+	ThreadID string    `json:"thread-id,omitempty"`
+}
+
+// BuildAPNsPayload renders msg as an APNs JSON payload. Data keys go next to
+// the aps dictionary, where the app reads custom values from.
+func BuildAPNsPayload(msg *PushMessage) ([]byte, error) {
+	payload := map[string]interface{}{
+		"aps": apnsAps{
+			Alert:    apnsAlert{Title: msg.Title, Body: msg.Body},
+			Sound:    "default",
+			ThreadID: msg.CollapseKey,
+		},
+	}
+	for key, value := range msg.Data {
+		if key != "aps" {
+			payload[key] = value
+		}
+	}
+	return json.Marshal(payload)
+}
+
+// NewPushProvider builds the live FCM and APNs providers when push.driver is
+// "live" and a LogPushProvider otherwise.
+func NewPushProvider(conf *config.Push, logger util.Logger) PushProvider {
+	if conf == nil || conf.Driver != "live" {
+		return NewLogPushProvider(logger)
+	}
+
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+
+	providers := make(map[string]PushProvider)
+	if conf.FCM.ProjectID != "" {
+		fcm := NewFCMProvider(client, conf.FCM.ProjectID, StaticPushToken(conf.FCM.AccessToken))
+		providers[util.PLATFORM_ANDROID] = fcm
+		providers[util.PLATFORM_WEB] = fcm
+	}
+	if conf.APNs.KeyPath != "" {
+		apns, err := NewAPNsProvider(client, &conf.APNs)
+		if err != nil {
+			logger.Error("APNs push provider disabled", zap.Error(err))
+		} else {
+			providers[util.PLATFORM_IOS] = apns
+		}
+	}
+
+	return NewPlatformPushProvider(providers)
+}
+
+type PlatformPushProvider struct {
+	providers map[string]PushProvider
+}
+
+// NewPlatformPushProvider routes each target to the provider registered for
+// its platform.
+func NewPlatformPushProvider(providers map[string]PushProvider) PushProvider {
+	return &PlatformPushProvider{providers: providers}
+}
+
+func (p *PlatformPushProvider) Send(ctx context.Context, target *PushTarget, msg *PushMessage) error {
+	provider, ok := p.providers[target.Platform]
+	if !ok {
+		return fmt.Errorf("no push provider configured for platform %s", target.Platform)
+	}
+	return provider.Send(ctx, target, msg)
+}
+
+// LogPushProvider logs the platform payload of every push instead of sending
+// it. Unlike FakePushProvider it keeps nothing, so it is safe to run for the
+// lifetime of a process.
+type LogPushProvider struct {
+	logger util.Logger
+}
+
+func NewLogPushProvider(logger util.Logger) PushProvider {
+	return &LogPushProvider{logger: logger}
+}
+
+func (l *LogPushProvider) Send(ctx context.Context, target *PushTarget, msg *PushMessage) error {
+	payload, err := buildPushPayload(target, msg)
+	if err != nil {
+		return err
+	}
+
+	l.logger.Info("Push logged instead of sent",
+		zap.String("platform", target.Platform),
+		zap.ByteString("payload", payload),
+	)
+	return nil
+}
+
+func buildPushPayload(target *PushTarget, msg *PushMessage) ([]byte, error) {
+	if target.Platform == util.PLATFORM_IOS {
+		return BuildAPNsPayload(msg)
+	}
+	return BuildFCMPayload(target.Token, msg)
+}
+
+type SentPush struct {
+	Platform string
+	Token    string
+	Payload  json.RawMessage
+}
+
+// FakePushProvider records the platform payload of every push instead of
+// sending it, for tests. Tokens passed to MarkInvalid fail the way a real
+// provider reports an unregistered token.
+type FakePushProvider struct {
+	mu      sync.Mutex
+	sent    []SentPush
+	invalid map[string]bool
+	logger  util.Logger
+}
+
+func NewFakePushProvider(logger util.Logger) *FakePushProvider {
+	return &FakePushProvider{
+		invalid: make(map[string]bool),
+		logger:  logger,
+	}
+}
+
+func (f *FakePushProvider) Send(ctx context.Context, target *PushTarget, msg *PushMessage) error {
+	payload, err := buildPushPayload(target, msg)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.invalid[target.Token] {
+		return fmt.Errorf("%w: token not registered", ErrInvalidPushToken)
+	}
+
+	f.sent = append(f.sent, SentPush{
+		Platform: target.Platform,
+		Token:    target.Token,
+		Payload:  payload,
+	})
+	if f.logger != nil {
+		f.logger.Info("Push recorded by fake provider",
+			zap.String("platform", target.Platform),
+			zap.ByteString("payload", payload),
+		)
+	}
+
+	return nil
+}
+
+func (f *FakePushProvider) MarkInvalid(token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.invalid[token] = true
+}
+
+func (f *FakePushProvider) Sent() []SentPush {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]SentPush(nil), f.sent...)
+}
+
+func (f *FakePushProvider) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = nil
+	f.invalid = make(map[string]bool)
+}
@@ -0,0 +1,134 @@
+package infrastructures
+
+import (
+	"bootcamp-content-interaction-service/config"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const apnsTokenLifetime = 50 * time.Minute
+
+type APNsProvider struct {
+	client   *http.Client
+	endpoint string
+	topic    string
+	keyID    string
+	teamID   string
+	key      *ecdsa.PrivateKey
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// NewAPNsProvider authenticates with a .p8 signing key. Apple rejects
+// provider tokens older than an hour, so the signed token is reused for
+// apnsTokenLifetime and then re-signed.
+func NewAPNsProvider(client *http.Client, conf *config.APNs) (PushProvider, error) {
+	pem, err := os.ReadFile(conf.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := "https://api.sandbox.push.apple.com"
+	if conf.Production {
+		endpoint = "https://api.push.apple.com"
+	}
+
+	return &APNsProvider{
+		client:   client,
+		endpoint: endpoint,
+		topic:    conf.Topic,
+		keyID:    conf.KeyID,
+		teamID:   conf.TeamID,
+		key:      key,
+	}, nil
+}
+
+func (p *APNsProvider) providerToken() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Since(p.issuedAt) < apnsTokenLifetime {
+		return p.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": p.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = p.keyID
+
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = signed
+	p.issuedAt = now
+	return signed, nil
+}
+
+func (p *APNsProvider) Send(ctx context.Context, target *PushTarget, msg *PushMessage) error {
+	payload, err := BuildAPNsPayload(msg)
+	if err != nil {
+		return err
+	}
+
+	token, err := p.providerToken()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/3/device/"+target.Token, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", p.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	if msg.CollapseKey != "" {
+		req.Header.Set("apns-collapse-id", msg.CollapseKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.Unmarshal(body, &apnsErr)
+
+	switch {
+	case resp.StatusCode == http.StatusGone,
+		apnsErr.Reason == "BadDeviceToken",
+		apnsErr.Reason == "DeviceTokenNotForTopic":
+		return fmt.Errorf("%w: %s", ErrInvalidPushToken, apnsErr.Reason)
+	default:
+		return fmt.Errorf("apns send failed: %s %s", resp.Status, apnsErr.Reason)
+	}
+}
@@ -0,0 +1,94 @@
+package infrastructures
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// PushTokenSource returns the OAuth2 access token used for FCM requests.
+type PushTokenSource func(ctx context.Context) (string, error)
+
+// StaticPushToken serves a token minted outside the service, for example by
+// a sidecar that refreshes push.fcm.access_token.
+func StaticPushToken(token string) PushTokenSource {
+	return func(ctx context.Context) (string, error) {
+		if token == "" {
+			return "", fmt.Errorf("fcm access token is not configured")
+		}
+		return token, nil
+	}
+}
+
+type FCMProvider struct {
+	client   *http.Client
+	endpoint string
+	token    PushTokenSource
+}
+
+func NewFCMProvider(client *http.Client, projectID string, token PushTokenSource) PushProvider {
+	return &FCMProvider{
+		client:   client,
+		endpoint: "https://fcm.googleapis.com/v1/projects/" + projectID + "/messages:send",
+		token:    token,
+	}
+}
+
+type fcmError struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (p *FCMProvider) Send(ctx context.Context, target *PushTarget, msg *PushMessage) error {
+	payload, err := BuildFCMPayload(target.Token, msg)
+	if err != nil {
+		return err
+	}
+
+	accessToken, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var fcmErr fcmError
+	_ = json.Unmarshal(body, &fcmErr)
+
+	for _, detail := range fcmErr.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return fmt.Errorf("%w: %s", ErrInvalidPushToken, fcmErr.Error.Message)
+		}
+	}
+	if fcmErr.Error.Status == "NOT_FOUND" ||
+		(fcmErr.Error.Status == "INVALID_ARGUMENT" && strings.Contains(fcmErr.Error.Message, "registration token")) {
+		return fmt.Errorf("%w: %s", ErrInvalidPushToken, fcmErr.Error.Message)
+	}
+
+	return fmt.Errorf("fcm send failed: %s %s", resp.Status, fcmErr.Error.Message)
+}
@@ -2,25 +2,50 @@ package main
 
 import (
 	comments "bootcamp-content-interaction-service/domains/comments/entities"
+	devices "bootcamp-content-interaction-service/domains/devices/entities"
 	likes "bootcamp-content-interaction-service/domains/likes/entities"
+	media "bootcamp-content-interaction-service/domains/media/entities"
 	users "bootcamp-content-interaction-service/domains/users/entities"
 	posts "bootcamp-content-interaction-service/domains/posts/entities"
+	postRepo "bootcamp-content-interaction-service/domains/posts/repositories"
 	notifications "bootcamp-content-interaction-service/domains/notifications/entities"
+	outbox "bootcamp-content-interaction-service/domains/outbox/entities"
+	relations "bootcamp-content-interaction-service/domains/relations/entities"
+	webhooks "bootcamp-content-interaction-service/domains/webhooks/entities"
 	"bootcamp-content-interaction-service/wizards"
+	"context"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
+	if err := postRepo.MigratePostImageKeys(wizards.PostgresDatabase.GetInstance()); err != nil {
+		panic(err)
+	}
+
 	wizards.PostgresDatabase.GetInstance().AutoMigrate(
 		&users.User{},
 		&posts.Post{},
+		&posts.PostSave{},
+		&media.MediaObject{},
+		&media.UploadSession{},
 		&likes.Likes{},
 		&comments.Comments{},
 		&notifications.Notification{},
+		&notifications.NotificationPreference{},
+		&notifications.NotificationMute{},
+		&notifications.NotificationDigest{},
+		&outbox.OutboxEvent{},
+		&devices.DeviceToken{},
+		&relations.UserRelation{},
+		&webhooks.WebhookSubscription{},
+		&webhooks.WebhookDelivery{},
+		&webhooks.WebhookAttempt{},
 	)
 
+	wizards.StartBackgroundWorkers(context.Background())
+
 	router := gin.Default()
 
 	wizards.RegisterServer(router)
@@ -0,0 +1,5 @@
+// Package usergraphv1 holds the generated client and server for the user
+// graph gRPC API. Regenerate after editing user_graph.proto.
+package usergraphv1
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative proto/usergraph/v1/user_graph.proto
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/usergraph/v1/user_graph.proto
+
+package usergraphv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsRequest) Reset() {
+	*x = ListRelationsRequest{}
+	mi := &file_proto_usergraph_v1_user_graph_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsRequest) ProtoMessage() {}
+
+func (x *ListRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_usergraph_v1_user_graph_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsRequest.ProtoReflect.Descriptor instead.
+func (*ListRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_usergraph_v1_user_graph_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRelationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRelationsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRelationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRelationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsResponse) Reset() {
+	*x = ListRelationsResponse{}
+	mi := &file_proto_usergraph_v1_user_graph_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsResponse) ProtoMessage() {}
+
+func (x *ListRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_usergraph_v1_user_graph_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsResponse.ProtoReflect.Descriptor instead.
+func (*ListRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_usergraph_v1_user_graph_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRelationsResponse) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *ListRelationsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_proto_usergraph_v1_user_graph_proto protoreflect.FileDescriptor
+
+const file_proto_usergraph_v1_user_graph_proto_rawDesc = "" +
+	"\n" +
+	"#proto/usergraph/v1/user_graph.proto\x12\fusergraph.v1\"]\n" +
+	"\x14ListRelationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"S\n" +
+	"\x15ListRelationsResponse\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor2\xc0\x01\n" +
+	"\tUserGraph\x12X\n" +
+	"\rListFollowers\x12\".usergraph.v1.ListRelationsRequest\x1a#.usergraph.v1.ListRelationsResponse\x12Y\n" +
+	"\x0eListFollowings\x12\".usergraph.v1.ListRelationsRequest\x1a#.usergraph.v1.ListRelationsResponseBEZCbootcamp-content-interaction-service/proto/usergraph/v1;usergraphv1b\x06proto3"
+
+var (
+	file_proto_usergraph_v1_user_graph_proto_rawDescOnce sync.Once
+	file_proto_usergraph_v1_user_graph_proto_rawDescData []byte
+)
+
+func file_proto_usergraph_v1_user_graph_proto_rawDescGZIP() []byte {
+	file_proto_usergraph_v1_user_graph_proto_rawDescOnce.Do(func() {
+		file_proto_usergraph_v1_user_graph_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_usergraph_v1_user_graph_proto_rawDesc), len(file_proto_usergraph_v1_user_graph_proto_rawDesc)))
+	})
+	return file_proto_usergraph_v1_user_graph_proto_rawDescData
+}
+
+var file_proto_usergraph_v1_user_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_usergraph_v1_user_graph_proto_goTypes = []any{
+	(*ListRelationsRequest)(nil),  // 0: usergraph.v1.ListRelationsRequest
+	(*ListRelationsResponse)(nil), // 1: usergraph.v1.ListRelationsResponse
+}
+var file_proto_usergraph_v1_user_graph_proto_depIdxs = []int32{
+	0, // 0: usergraph.v1.UserGraph.ListFollowers:input_type -> usergraph.v1.ListRelationsRequest
+	0, // 1: usergraph.v1.UserGraph.ListFollowings:input_type -> usergraph.v1.ListRelationsRequest
+	1, // 2: usergraph.v1.UserGraph.ListFollowers:output_type -> usergraph.v1.ListRelationsResponse
+	1, // 3: usergraph.v1.UserGraph.ListFollowings:output_type -> usergraph.v1.ListRelationsResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_usergraph_v1_user_graph_proto_init() }
+func file_proto_usergraph_v1_user_graph_proto_init() {
+	if File_proto_usergraph_v1_user_graph_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_usergraph_v1_user_graph_proto_rawDesc), len(file_proto_usergraph_v1_user_graph_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_usergraph_v1_user_graph_proto_goTypes,
+		DependencyIndexes: file_proto_usergraph_v1_user_graph_proto_depIdxs,
+		MessageInfos:      file_proto_usergraph_v1_user_graph_proto_msgTypes,
+	}.Build()
+	File_proto_usergraph_v1_user_graph_proto = out.File
+	file_proto_usergraph_v1_user_graph_proto_goTypes = nil
+	file_proto_usergraph_v1_user_graph_proto_depIdxs = nil
+}
@@ -0,0 +1,159 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/usergraph/v1/user_graph.proto
+
+package usergraphv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserGraph_ListFollowers_FullMethodName  = "/usergraph.v1.UserGraph/ListFollowers"
+	UserGraph_ListFollowings_FullMethodName = "/usergraph.v1.UserGraph/ListFollowings"
+)
+
+// UserGraphClient is the client API for UserGraph service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserGraphClient interface {
+	ListFollowers(ctx context.Context, in *ListRelationsRequest, opts ...grpc.CallOption) (*ListRelationsResponse, error)
+	ListFollowings(ctx context.Context, in *ListRelationsRequest, opts ...grpc.CallOption) (*ListRelationsResponse, error)
+}
+
+type userGraphClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserGraphClient(cc grpc.ClientConnInterface) UserGraphClient {
+	return &userGraphClient{cc}
+}
+
+func (c *userGraphClient) ListFollowers(ctx context.Context, in *ListRelationsRequest, opts ...grpc.CallOption) (*ListRelationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRelationsResponse)
+	err := c.cc.Invoke(ctx, UserGraph_ListFollowers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGraphClient) ListFollowings(ctx context.Context, in *ListRelationsRequest, opts ...grpc.CallOption) (*ListRelationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRelationsResponse)
+	err := c.cc.Invoke(ctx, UserGraph_ListFollowings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserGraphServer is the server API for UserGraph service.
+// All implementations must embed UnimplementedUserGraphServer
+// for forward compatibility.
+type UserGraphServer interface {
+	ListFollowers(context.Context, *ListRelationsRequest) (*ListRelationsResponse, error)
+	ListFollowings(context.Context, *ListRelationsRequest) (*ListRelationsResponse, error)
+	mustEmbedUnimplementedUserGraphServer()
+}
+
+// UnimplementedUserGraphServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserGraphServer struct{}
+
+func (UnimplementedUserGraphServer) ListFollowers(context.Context, *ListRelationsRequest) (*ListRelationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowers not implemented")
+}
+func (UnimplementedUserGraphServer) ListFollowings(context.Context, *ListRelationsRequest) (*ListRelationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowings not implemented")
+}
+func (UnimplementedUserGraphServer) mustEmbedUnimplementedUserGraphServer() {}
+func (UnimplementedUserGraphServer) testEmbeddedByValue()                   {}
+
+// UnsafeUserGraphServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserGraphServer will
+// result in compilation errors.
+type UnsafeUserGraphServer interface {
+	mustEmbedUnimplementedUserGraphServer()
+}
+
+func RegisterUserGraphServer(s grpc.ServiceRegistrar, srv UserGraphServer) {
+	// If the following call pancis, it indicates UnimplementedUserGraphServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserGraph_ServiceDesc, srv)
+}
+
+func _UserGraph_ListFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGraphServer).ListFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGraph_ListFollowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGraphServer).ListFollowers(ctx, req.(*ListRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGraph_ListFollowings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGraphServer).ListFollowings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGraph_ListFollowings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGraphServer).ListFollowings(ctx, req.(*ListRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserGraph_ServiceDesc is the grpc.ServiceDesc for UserGraph service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserGraph_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "usergraph.v1.UserGraph",
+	HandlerType: (*UserGraphServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFollowers",
+			Handler:    _UserGraph_ListFollowers_Handler,
+		},
+		{
+			MethodName: "ListFollowings",
+			Handler:    _UserGraph_ListFollowings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/usergraph/v1/user_graph.proto",
+}
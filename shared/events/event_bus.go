@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Event struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+func (e *Event) Decode(target interface{}) error {
+	return json.Unmarshal(e.Payload, target)
+}
+
+type Handler func(ctx context.Context, event *Event) error
+
+type EventBus interface {
+	Subscribe(eventType string, subscriber string, handler Handler)
+	Publish(ctx context.Context, event *Event, delivered []string) ([]string, error)
+}
+
+type subscription struct {
+	name    string
+	handler Handler
+}
+
+// InMemoryEventBus delivers events to the subscribers registered in this
+// process. Publish is told which subscribers already handled the event on an
+// earlier attempt and skips them, so a retry only reaches the ones that failed.
+type InMemoryEventBus struct {
+	mu            sync.RWMutex
+	subscriptions map[string][]subscription
+}
+
+func NewInMemoryEventBus() EventBus {
+	return &InMemoryEventBus{
+		subscriptions: make(map[string][]subscription),
+	}
+}
+
+func (b *InMemoryEventBus) Subscribe(eventType string, subscriber string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscriptions[eventType] = append(b.subscriptions[eventType], subscription{
+		name:    subscriber,
+		handler: handler,
+	})
+}
+
+func (b *InMemoryEventBus) Publish(ctx context.Context, event *Event, delivered []string) ([]string, error) {
+	b.mu.RLock()
+	subscriptions := b.subscriptions[event.Type]
+	b.mu.RUnlock()
+
+	done := make(map[string]bool, len(delivered))
+	for _, name := range delivered {
+		done[name] = true
+	}
+
+	var errs []error
+	for _, sub := range subscriptions {
+		if done[sub.name] {
+			continue
+		}
+
+		if err := sub.handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+			continue
+		}
+		delivered = append(delivered, sub.name)
+	}
+
+	return delivered, errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	POST_CREATED    = "PostCreated"
+	POST_UPDATED    = "PostUpdated"
+	POST_DELETED    = "PostDeleted"
+	LIKE_ADDED      = "LikeAdded"
+	LIKE_REMOVED    = "LikeRemoved"
+	COMMENT_CREATED = "CommentCreated"
+	COMMENT_UPDATED = "CommentUpdated"
+	COMMENT_DELETED = "CommentDeleted"
+	POST_SAVED      = "PostSaved"
+	POST_UNSAVED    = "PostUnsaved"
+)
+
+const (
+	RELATION_CREATED = "RelationCreated"
+	RELATION_DELETED = "RelationDeleted"
+)
+
+type PostPayload struct {
+	PostID  uuid.UUID `json:"post_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Caption string    `json:"caption"`
+}
+
+type LikePayload struct {
+	PostID      uuid.UUID `json:"post_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	PostOwnerID uuid.UUID `json:"post_owner_id"`
+	// EngagedAt is set on LikeRemoved to when the removed like was made.
+	EngagedAt *time.Time `json:"engaged_at,omitempty"`
+}
+
+type SavePayload struct {
+	PostID      uuid.UUID `json:"post_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	PostOwnerID uuid.UUID `json:"post_owner_id"`
+	// EngagedAt is set on PostUnsaved to when the removed save was made.
+	EngagedAt *time.Time `json:"engaged_at,omitempty"`
+}
+
+type CommentPayload struct {
+	CommentID     uuid.UUID  `json:"comment_id"`
+	PostID        uuid.UUID  `json:"post_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	PostOwnerID   uuid.UUID  `json:"post_owner_id"`
+	ReplyID       *uuid.UUID `json:"reply_id,omitempty"`
+	ReplyToUserID *uuid.UUID `json:"reply_to_user_id,omitempty"`
+	Msg           string     `json:"msg"`
+	CreatedAt     time.Time  `json:"created_at"`
+	// EngagedAt is set on CommentDeleted to when the comment was made.
+	EngagedAt *time.Time `json:"engaged_at,omitempty"`
+}
+
+type RelationPayload struct {
+	UserID       uuid.UUID `json:"user_id"`
+	TargetUserID uuid.UUID `json:"target_user_id"`
+	Type         string    `json:"type"`
+}
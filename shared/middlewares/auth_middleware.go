@@ -5,6 +5,7 @@ import (
 	"bootcamp-content-interaction-service/shared/constant"
 	"bootcamp-content-interaction-service/shared/models/responses"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -17,24 +18,57 @@ func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
 
-		token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
-			return constant.JWT_SECRET, nil
-		})
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.BasicResponse{Error: "Unauthorized"})
+		authUser, err := ParseAccessToken(accessToken)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.BasicResponse{Error: err.Error()})
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.BasicResponse{Error: "Invalid claims"})
+		ctx := context.WithValue(c.Request.Context(), "user", authUser)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware attaches the user when a valid token is sent and
+// lets anonymous requests through, for public routes that personalise their
+// response for signed-in viewers.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if accessToken == "" {
+			c.Next()
+			return
+		}
+
+		authUser, err := ParseAccessToken(accessToken)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.BasicResponse{Error: err.Error()})
 			return
 		}
 
-		authUser := &dto.AuthUserDto{
-			UserId: fmt.Sprintf("%v", claims["id"]),
-			Name:   fmt.Sprintf("%v", claims["name"]),
-			Email:  fmt.Sprintf("%v", claims["email"]),
+		ctx := context.WithValue(c.Request.Context(), "user", authUser)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// WebSocketAuthMiddleware authenticates upgrade requests. Browsers cannot set
+// the Authorization header on a WebSocket handshake, so the token may also be
+// passed through the access_token query parameter.
+func WebSocketAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if accessToken == "" {
+			accessToken = c.Query("access_token")
+		}
+
+		authUser, err := ParseAccessToken(accessToken)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.BasicResponse{Error: err.Error()})
+			return
 		}
 
 		ctx := context.WithValue(c.Request.Context(), "user", authUser)
@@ -43,3 +77,23 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func ParseAccessToken(accessToken string) (*dto.AuthUserDto, error) {
+	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
+		return constant.JWT_SECRET, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errors.New("Unauthorized")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("Invalid claims")
+	}
+
+	return &dto.AuthUserDto{
+		UserId: fmt.Sprintf("%v", claims["id"]),
+		Name:   fmt.Sprintf("%v", claims["name"]),
+		Email:  fmt.Sprintf("%v", claims["email"]),
+	}, nil
+}
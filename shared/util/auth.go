@@ -14,3 +14,11 @@ func GetAuthUser(ctx context.Context) (*dto.AuthUserDto, error) {
 	}
 	return user, nil
 }
+
+func GetCallingService(ctx context.Context) (string, error) {
+	service, ok := ctx.Value("service").(string)
+	if !ok || service == "" {
+		return "", errors.New("unauthorized: service not found in context")
+	}
+	return service, nil
+}
@@ -0,0 +1,127 @@
+package util
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor points just past the last row of a page ordered by
+// (created_at DESC, id DESC).
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// After reports whether a row sorts after the cursor in
+// (created_at DESC, id DESC) order, i.e. belongs on a later page.
+func (c *Cursor) After(createdAt time.Time, id uuid.UUID) bool {
+	if c == nil {
+		return true
+	}
+	if !createdAt.Equal(c.CreatedAt) {
+		return createdAt.Before(c.CreatedAt)
+	}
+	return strings.Compare(id.String(), c.ID.String()) < 0
+}
+
+// EncodeSnapshotCursor points at offset within a stored ranking snapshot.
+func EncodeSnapshotCursor(token string, offset int) string {
+	raw := token + "|" + strconv.Itoa(offset)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeSnapshotCursor(encoded string) (string, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", 0, errors.New("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", 0, errors.New("invalid cursor")
+	}
+
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return "", 0, errors.New("invalid cursor")
+	}
+
+	return parts[0], offset, nil
+}
+
+// ScoreCursor points just past the last row of a page ordered by
+// (score DESC, id DESC).
+type ScoreCursor struct {
+	Score float64
+	ID    uuid.UUID
+}
+
+func EncodeScoreCursor(score float64, id uuid.UUID) string {
+	raw := strconv.FormatFloat(score, 'g', -1, 64) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeScoreCursor(encoded string) (*ScoreCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	score, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || math.IsNaN(score) || math.IsInf(score, 0) {
+		return nil, errors.New("invalid cursor")
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &ScoreCursor{Score: score, ID: id}, nil
+}
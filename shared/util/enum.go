@@ -1,3 +1,69 @@
 package util
 
-const NOTIF_POST = "NEW_POST"
\ No newline at end of file
+const NOTIF_POST = "NEW_POST"
+const NOTIF_LIKE = "LIKE"
+const NOTIF_COMMENT = "COMMENT"
+const NOTIF_REPLY = "REPLY"
+const NOTIF_MENTION = "MENTION"
+
+const (
+	EVENT_COMMENT_CREATED    = "COMMENT_CREATED"
+	EVENT_LIKE_COUNT_UPDATED = "LIKE_COUNT_UPDATED"
+	EVENT_RELATION_CHANGED   = "RELATION_CHANGED"
+)
+
+const (
+	WEBHOOK_PENDING   = "PENDING"
+	WEBHOOK_SUCCEEDED = "SUCCEEDED"
+	WEBHOOK_FAILED    = "FAILED"
+)
+
+const (
+	DIGEST_NONE   = "NONE"
+	DIGEST_DAILY  = "DAILY"
+	DIGEST_WEEKLY = "WEEKLY"
+)
+
+const (
+	DIGEST_SENDING = "SENDING"
+	DIGEST_SENT    = "SENT"
+)
+
+const (
+	PLATFORM_ANDROID = "ANDROID"
+	PLATFORM_IOS     = "IOS"
+	PLATFORM_WEB     = "WEB"
+)
+
+const (
+	RELATION_BLOCK = "BLOCK"
+	RELATION_MUTE  = "MUTE"
+)
+
+const (
+	FEED_CHRONOLOGICAL = "chronological"
+	FEED_RANKED        = "ranked"
+)
+
+const (
+	RENDITION_THUMBNAIL = "thumbnail"
+	RENDITION_FEED      = "feed"
+	RENDITION_FULL      = "full"
+	RENDITION_VIDEO     = "video"
+)
+
+const (
+	UPLOAD_PENDING    = "PENDING"
+	UPLOAD_PROCESSING = "PROCESSING"
+	UPLOAD_COMPLETED  = "COMPLETED"
+)
+
+const (
+	MEDIA_IMAGE = "image"
+	MEDIA_VIDEO = "video"
+)
+
+const (
+	SEARCH_WORDS  = "words"
+	SEARCH_PREFIX = "prefix"
+)
@@ -45,6 +45,13 @@ func NewLogger() (Logger, error) {
 	}, nil
 }
 
+// NewNopLogger returns a Logger that discards everything, for tests.
+func NewNopLogger() Logger {
+	return &zapLogger{
+		logger: zap.NewNop(),
+	}
+}
+
 func (l *zapLogger) Info(msg string, fields ...zap.Field) {
 	l.logger.Info(msg, fields...)
 }
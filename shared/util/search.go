@@ -0,0 +1,53 @@
+package util
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TextSearch is one page of a full-text search. Matches score
+// ln(ts_rank) + ln(2)*created_at/HalfLife, so a match's text rank counts half
+// as much for every HalfLife it is older than another. The score of a row
+// never changes, which keeps cursors valid between pages.
+type TextSearch struct {
+	Query          string
+	Prefix         bool
+	HalfLife       time.Duration
+	ExcludeUserIDs []string
+	Cursor         *ScoreCursor
+	Limit          int
+}
+
+// TSQuery returns the SQL expression of the search's tsquery and its
+// argument, and false when the query has nothing to search for. Whole-word
+// searches accept web search syntax: quoted phrases, "or" and a leading
+// "-" to exclude a word. Prefix searches, meant for search-as-you-type,
+// match every word typed so far and treat the last one as a prefix.
+//
+// The text search configuration is 'simple', the one the search_vector
+// columns are generated with: captions and comments come in more than one
+// language, so words are only lowercased, never stemmed.
+func (s TextSearch) TSQuery() (string, string, bool) {
+	if !s.Prefix {
+		return "websearch_to_tsquery('simple', ?)", s.Query, strings.TrimSpace(s.Query) != ""
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(s.Query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "", "", false
+	}
+	words[len(words)-1] += ":*"
+	return "to_tsquery('simple', ?)", strings.Join(words, " & "), true
+}
+
+// ScoreSQL returns the SQL expression scoring a row by its tsvector column
+// against search_query, the alias the tsquery is selected as, and its
+// created_at column, along with its argument.
+func (s TextSearch) ScoreSQL(vectorColumn string, createdAtColumn string) (string, float64) {
+	expr := "ln(greatest(ts_rank(" + vectorColumn + ", search_query), 1e-9)) + extract(epoch from " + createdAtColumn + ") * ?::float8"
+	return expr, math.Ln2 / s.HalfLife.Seconds()
+}
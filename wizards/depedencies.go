@@ -2,19 +2,47 @@ package wizards
 
 import (
 	"bootcamp-content-interaction-service/config"
+	commentsEvents "bootcamp-content-interaction-service/domains/comments/handlers/events"
 	commentsHttp "bootcamp-content-interaction-service/domains/comments/handlers/http"
 	commentsRepository "bootcamp-content-interaction-service/domains/comments/repositories"
 	commentsUc "bootcamp-content-interaction-service/domains/comments/usecases"
+	deviceHttp "bootcamp-content-interaction-service/domains/devices/handlers/http"
+	deviceRepo "bootcamp-content-interaction-service/domains/devices/repositories"
+	deviceUc "bootcamp-content-interaction-service/domains/devices/usecases"
 	likesHttp "bootcamp-content-interaction-service/domains/likes/handlers/http"
 	likesRepository "bootcamp-content-interaction-service/domains/likes/repositories"
 	likesUc "bootcamp-content-interaction-service/domains/likes/usecases"
+	mediaHttp "bootcamp-content-interaction-service/domains/media/handlers/http"
+	mediaRepo "bootcamp-content-interaction-service/domains/media/repositories"
+	mediaUc "bootcamp-content-interaction-service/domains/media/usecases"
+	mediaWorkers "bootcamp-content-interaction-service/domains/media/workers"
+	outboxRepo "bootcamp-content-interaction-service/domains/outbox/repositories"
+	outboxWorkers "bootcamp-content-interaction-service/domains/outbox/workers"
+	postEvents "bootcamp-content-interaction-service/domains/posts/handlers/events"
+	postGrpc "bootcamp-content-interaction-service/domains/posts/handlers/grpc"
 	postHttp "bootcamp-content-interaction-service/domains/posts/handlers/http"
 	postRepo "bootcamp-content-interaction-service/domains/posts/repositories"
 	postUc "bootcamp-content-interaction-service/domains/posts/usecases"
+	postWorkers "bootcamp-content-interaction-service/domains/posts/workers"
+	notificationEvents "bootcamp-content-interaction-service/domains/notifications/handlers/events"
 	notificationHttp "bootcamp-content-interaction-service/domains/notifications/handlers/http"
 	notificationRepo "bootcamp-content-interaction-service/domains/notifications/repositories"
 	notificationUc "bootcamp-content-interaction-service/domains/notifications/usecases"
+	notificationWorkers "bootcamp-content-interaction-service/domains/notifications/workers"
+	relationHttp "bootcamp-content-interaction-service/domains/relations/handlers/http"
+	relationRepo "bootcamp-content-interaction-service/domains/relations/repositories"
+	relationUc "bootcamp-content-interaction-service/domains/relations/usecases"
+	realtimeEvents "bootcamp-content-interaction-service/domains/realtime/handlers/events"
+	realtimeRepo "bootcamp-content-interaction-service/domains/realtime/repositories"
+	realtimeWs "bootcamp-content-interaction-service/domains/realtime/handlers/ws"
+	webhookEvents "bootcamp-content-interaction-service/domains/webhooks/handlers/events"
+	webhookHttp "bootcamp-content-interaction-service/domains/webhooks/handlers/http"
+	webhookRepo "bootcamp-content-interaction-service/domains/webhooks/repositories"
+	webhookUc "bootcamp-content-interaction-service/domains/webhooks/usecases"
+	webhookWorkers "bootcamp-content-interaction-service/domains/webhooks/workers"
 	"bootcamp-content-interaction-service/infrastructures"
+	"net/http"
+	"bootcamp-content-interaction-service/shared/events"
 	"bootcamp-content-interaction-service/shared/util"
 )
 
@@ -24,21 +52,120 @@ var (
 	RedisClient 	    = infrastructures.InitRedis()
 	LoggerInstance, _ 	= util.NewLogger()
 	
-	UserGraphService    = postHttp.NewUserGraphHTTP(Config.Server.UserGraphBaseURL)
+	UserGraphService    = postHttp.NewCachedUserGraph(
+		newUserGraphTransport(),
+		RedisClient, Config.UserGraph.WithDefaults().CacheTTL, LoggerInstance,
+	)
 
-	LikesRepository     = likesRepository.NewLikesRepository(PostgresDatabase)
-	LikesUseCase        = likesUc.NewLikesUseCase(LikesRepository)
+	BlobStore           = newBlobStore()
+	MediaURLSigner      = newMediaURLSigner()
+	MediaUseCase        = mediaUc.NewMediaUseCase(BlobStore, MediaURLSigner)
+	MediaRepository     = mediaRepo.NewMediaRepository(PostgresDatabase, LoggerInstance)
+	ImagePipeline       = mediaUc.NewImagePipeline(BlobStore, MediaRepository, Config.Media, LoggerInstance)
+	MediaProbe          = infrastructures.NewMediaProbe(Config.Media, LoggerInstance)
+	VideoPipeline       = mediaUc.NewVideoPipeline(BlobStore, MediaRepository, MediaProbe, Config.Media, LoggerInstance)
+	MediaHttp           = mediaHttp.NewMediaHttp(MediaUseCase)
+	UploadRepository    = mediaRepo.NewUploadRepository(PostgresDatabase, LoggerInstance)
+	UploadUseCase       = mediaUc.NewUploadUseCase(UploadRepository, BlobStore, ImagePipeline, VideoPipeline, Config.Media, LoggerInstance)
+	UploadHttp          = mediaHttp.NewUploadHttp(UploadUseCase)
+	UploadCleanupWorker = mediaWorkers.NewUploadCleanupWorker(UploadRepository, BlobStore, ImagePipeline, Config.Worker.UploadCleanup, LoggerInstance)
+
+	EventBus            = events.NewInMemoryEventBus()
+	OutboxRepository    = outboxRepo.NewOutboxRepository(PostgresDatabase, LoggerInstance)
+	OutboxRelay         = outboxWorkers.NewRelay(OutboxRepository, EventBus, LoggerInstance)
+
+	RelationRepository  = relationRepo.NewRelationRepository(PostgresDatabase, RedisClient, LoggerInstance, OutboxRepository)
+	RelationChecker     = relationUc.NewRelationChecker(RelationRepository)
+	RelationUseCase     = relationUc.NewRelationUseCase(RelationRepository)
+	RelationHttp        = relationHttp.NewRelationHttp(RelationUseCase)
+
+	RealtimeRepository  = realtimeRepo.NewRealtimeRepository(RedisClient, LoggerInstance)
+	RealtimeHub         = realtimeWs.NewHub(RealtimeRepository, LoggerInstance)
+	RealtimeWs          = realtimeWs.NewRealtimeWs(RealtimeHub, RelationChecker, LoggerInstance)
+	RealtimeEvents      = realtimeEvents.NewRealtimeEventHandler(RealtimeRepository, LikesRepository)
+
+	LikesRepository     = likesRepository.NewLikesRepository(PostgresDatabase, OutboxRepository)
+	LikesUseCase        = likesUc.NewLikesUseCase(LikesRepository, RelationChecker)
 	LikesHttp           = likesHttp.NewLikesHandler(LikesUseCase)
 
-	CommentsRepository  = commentsRepository.NewCommentsRepository(PostgresDatabase, RedisClient, LoggerInstance)
-	CommentsUseCase     = commentsUc.NewCommentsUseCase(CommentsRepository)
+	CommentsRepository  = commentsRepository.NewCommentsRepository(PostgresDatabase, RedisClient, LoggerInstance, OutboxRepository)
+	CommentsUseCase     = commentsUc.NewCommentsUseCase(CommentsRepository, RelationChecker, Config.Search)
 	CommentsHttp        = commentsHttp.NewLikesHandler(CommentsUseCase)
+	CommentsEvents      = commentsEvents.NewCommentEventHandler(CommentsRepository)
 
-	PostRepository      = postRepo.NewPostRepository(PostgresDatabase, RedisClient, LoggerInstance)
-    PostUseCase         = postUc.NewPostUseCase(PostRepository, UserGraphService, NotificationRepository)
+	PostRepository      = postRepo.NewPostRepository(PostgresDatabase, RedisClient, LoggerInstance, OutboxRepository)
+	PostSaveRepository  = postRepo.NewPostSaveRepository(PostgresDatabase, LoggerInstance, OutboxRepository)
+	ExploreRepository   = postRepo.NewExploreRepository(RedisClient, Config.Worker.Explore, LoggerInstance)
+	FeedSnapshotRepository = postRepo.NewFeedSnapshotRepository(RedisClient, Config.Feed.WithDefaults().SnapshotTTL, LoggerInstance)
+	SeenRepository      = postRepo.NewSeenRepository(RedisClient, Config.Feed, LoggerInstance)
+    AltTextGenerator    = infrastructures.NewNoopAltTextGenerator()
+    PostUseCase         = postUc.NewPostUseCase(PostRepository, PostSaveRepository, ExploreRepository, FeedSnapshotRepository, SeenRepository, ImagePipeline, UploadUseCase, MediaURLSigner, UserGraphService, RelationChecker, AltTextGenerator, Config.Feed, Config.Search, LoggerInstance)
 	PostHttp            = postHttp.NewPostHttp(PostUseCase)
+	UserGraphCacheHttp  = postHttp.NewUserGraphCacheHttp(UserGraphService)
+	PostEvents          = postEvents.NewPostEventHandler(PostRepository, ExploreRepository, Config.Worker.Explore)
+	ExploreWorker       = postWorkers.NewExploreWorker(PostRepository, ExploreRepository, Config.Worker.Explore, LoggerInstance)
 
 	NotificationRepository 	= notificationRepo.NewNotificationRepository(PostgresDatabase, RedisClient, LoggerInstance)
-	NotificationUseCase  	= notificationUc.NewNotificationUseCase(NotificationRepository)
+	NotificationPreferenceRepository = notificationRepo.NewNotificationPreferenceRepository(PostgresDatabase, LoggerInstance)
+	NotificationDispatcher  = notificationUc.NewNotificationDispatcher(NotificationRepository, NotificationPreferenceRepository, NotificationPushWorker, RelationRepository, LoggerInstance)
+	NotificationUseCase  	= notificationUc.NewNotificationUseCase(NotificationRepository, NotificationPreferenceRepository, NotificationDispatcher)
 	NotificationHttp		= notificationHttp.NewNotificationHttp(NotificationUseCase)
+
+	DeviceRepository = deviceRepo.NewDeviceRepository(PostgresDatabase, LoggerInstance)
+	DeviceUseCase    = deviceUc.NewDeviceUseCase(DeviceRepository)
+	DeviceHttp       = deviceHttp.NewDeviceHttp(DeviceUseCase)
+
+	PushProvider           = infrastructures.NewPushProvider(Config.Push, LoggerInstance)
+	NotificationPushQueue  = infrastructures.NewRedisStreamQueue(RedisClient, "notification_push", Config.Worker.NotificationPush, LoggerInstance)
+	NotificationPushWorker = notificationWorkers.NewPushWorker(NotificationPushQueue, DeviceRepository, PushProvider, LoggerInstance)
+
+	NotificationFanoutQueue  = infrastructures.NewRedisStreamQueue(RedisClient, "notification_fanout", Config.Worker.NotificationFanout, LoggerInstance)
+	NotificationFanoutWorker = notificationWorkers.NewFanoutWorker(NotificationFanoutQueue, UserGraphService, NotificationDispatcher, LoggerInstance)
+	NotificationEvents       = notificationEvents.NewNotificationEventHandler(NotificationFanoutWorker, NotificationDispatcher)
+	NotificationRetention    = notificationWorkers.NewRetentionWorker(NotificationRepository, Config.Worker.NotificationRetention, LoggerInstance)
+
+	Mailer                       = infrastructures.NewMailer(Config.Mail, LoggerInstance)
+	NotificationDigestRepository = notificationRepo.NewNotificationDigestRepository(PostgresDatabase, LoggerInstance)
+	NotificationDigestWorker     = notificationWorkers.NewDigestWorker(NotificationDigestRepository, Mailer, Config.Worker.NotificationDigest, LoggerInstance)
+
+	WebhookRepository     = webhookRepo.NewWebhookRepository(PostgresDatabase, LoggerInstance)
+	WebhookUseCase        = webhookUc.NewWebhookUseCase(WebhookRepository)
+	WebhookHttp           = webhookHttp.NewWebhookHttp(WebhookUseCase)
+	WebhookEvents         = webhookEvents.NewWebhookEventHandler(WebhookRepository)
+	WebhookDeliveryWorker = webhookWorkers.NewDeliveryWorker(WebhookRepository, webhookWorkers.NewHTTPSender(&http.Client{}), Config.Worker.Webhook, LoggerInstance)
 )
+
+// newUserGraphTransport picks the user graph client named by
+// user_graph.transport: "grpc" dials user_graph.grpc_address, anything else
+// uses JSON over HTTP at server.user_graph_base_url.
+func newUserGraphTransport() postHttp.UserGraphService {
+	if Config.UserGraph.Transport == "grpc" {
+		conn, err := postGrpc.DialUserGraph(Config.UserGraph.GRPCAddress)
+		if err != nil {
+			panic(err)
+		}
+		return postGrpc.NewUserGraphGRPC(conn, Config.UserGraph)
+	}
+	return postHttp.NewUserGraphHTTP(Config.Server.UserGraphBaseURL, &http.Client{}, Config.UserGraph)
+}
+
+func newBlobStore() infrastructures.BlobStore {
+	store, err := infrastructures.NewBlobStore(Config.Storage, LoggerInstance)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+func newMediaURLSigner() *infrastructures.URLSigner {
+	var storage config.Storage
+	if Config.Storage != nil {
+		storage = *Config.Storage
+	}
+	storage = storage.WithDefaults()
+	signer, err := infrastructures.NewURLSigner(storage.SigningSecret, storage.PublicBaseURL, storage.SignedURLTTL)
+	if err != nil {
+		panic(err)
+	}
+	return signer
+}
@@ -8,13 +8,32 @@ import (
 
 func RegisterServer(router *gin.Engine) {
   
+	internal := router.Group("/internal/v1")
+	{
+		internal.Use(middlewares.InternalAuthMiddleware(Config.Internal))
+
+		internal.POST("/notification/post", NotificationHttp.CreatePostNotification)
+		internal.POST("/user-graph/invalidate", UserGraphCacheHttp.Invalidate)
+
+		webhook := internal.Group("/webhooks")
+		{
+			webhook.POST("", WebhookHttp.CreateSubscription)
+			webhook.GET("", WebhookHttp.ViewAllSubscription)
+			webhook.GET("/:id", WebhookHttp.ViewSubscription)
+			webhook.PUT("/:id", WebhookHttp.UpdateSubscription)
+			webhook.DELETE("/:id", WebhookHttp.DeleteSubscription)
+			webhook.GET("/:id/deliveries", WebhookHttp.ViewAllDelivery)
+			webhook.GET("/deliveries/:delivery_id", WebhookHttp.ViewDelivery)
+			webhook.POST("/deliveries/:delivery_id/replay", WebhookHttp.ReplayDelivery)
+		}
+	}
+
 	api := router.Group("/v1")
 	{
 		post := api.Group("/posts")
 		{
-			post.GET("/view", PostHttp.ViewAllPost)
-			post.GET("/view/:id", PostHttp.ViewPostById)
-			post.GET("/view/feed/:id", PostHttp.ViewPersonalFeed)
+			post.GET("/view", middlewares.OptionalAuthMiddleware(), PostHttp.ViewAllPost)
+			post.GET("/view/:id", middlewares.OptionalAuthMiddleware(), PostHttp.ViewPostById)
 	
 			post.Use(middlewares.AuthMiddleware())
 			post.POST("/:id/likes", LikesHttp.LikePost)
@@ -28,16 +47,77 @@ func RegisterServer(router *gin.Engine) {
 
 			post.POST("/create", PostHttp.CreatePost)
 			post.GET("/view/user", PostHttp.ViewAllPostByUserId)
+			post.GET("/view/feed", PostHttp.ViewPersonalFeed)
+			post.GET("/view/feed/:id", PostHttp.ViewPersonalFeed)
+			post.GET("/view/saved", PostHttp.ViewSavedPost)
+			post.POST("/impressions", PostHttp.ReportImpressions)
+			post.POST("/:id/saves", PostHttp.AddSave)
+			post.DELETE("/:id/saves", PostHttp.RemoveSave)
 			post.DELETE("/delete/:id", PostHttp.DeletePost)
 			post.PATCH("/update/:id", PostHttp.UpdatePost)
+			post.POST("/:id/images", PostHttp.AddImages)
+			post.PUT("/:id/images/order", PostHttp.ReorderImages)
+			post.PATCH("/:id/images/:media_id", PostHttp.UpdateImage)
+			post.DELETE("/:id/images/:media_id", PostHttp.RemoveImage)
 		}
 
-		notification := api.Group("/notification")
+		search := api.Group("/search")
 		{
-			notification.POST("/post", NotificationHttp.CreatePostNotification)
+			search.Use(middlewares.OptionalAuthMiddleware())
+			search.GET("/posts", PostHttp.SearchPosts)
+			search.GET("/comments", CommentsHttp.SearchComments)
+		}
 
+		api.GET("/media/*key", MediaHttp.ViewMedia)
+
+		upload := api.Group("/uploads")
+		{
+			upload.Use(middlewares.AuthMiddleware())
+			upload.POST("", UploadHttp.InitiateUpload)
+			upload.GET("/:id", UploadHttp.ViewUpload)
+			upload.PUT("/:id", UploadHttp.WriteChunk)
+			upload.POST("/:id/complete", UploadHttp.CompleteUpload)
+		}
+
+		realtime := api.Group("/realtime")
+		{
+			realtime.Use(middlewares.WebSocketAuthMiddleware())
+			realtime.GET("/ws", RealtimeWs.Connect)
+		}
+
+		relation := api.Group("/relations")
+		{
+			relation.Use(middlewares.AuthMiddleware())
+			relation.POST("/blocks", RelationHttp.CreateBlock)
+			relation.GET("/blocks", RelationHttp.ViewAllBlock)
+			relation.DELETE("/blocks/:user_id", RelationHttp.DeleteBlock)
+			relation.POST("/mutes", RelationHttp.CreateMute)
+			relation.GET("/mutes", RelationHttp.ViewAllMute)
+			relation.DELETE("/mutes/:user_id", RelationHttp.DeleteMute)
+		}
+
+		device := api.Group("/devices")
+		{
+			device.Use(middlewares.AuthMiddleware())
+			device.POST("", DeviceHttp.RegisterDevice)
+			device.GET("", DeviceHttp.ViewAllDevice)
+			device.DELETE("/:id", DeviceHttp.DeleteDevice)
+		}
+
+		notification := api.Group("/notification")
+		{
 			notification.Use(middlewares.AuthMiddleware())
 			notification.GET("/post", NotificationHttp.ViewAllNotification)
+			notification.DELETE("", NotificationHttp.DeleteAllNotification)
+			notification.DELETE("/:id", NotificationHttp.DeleteNotification)
+			notification.POST("/read", NotificationHttp.MarkAllRead)
+			notification.POST("/:id/read", NotificationHttp.MarkRead)
+
+			notification.GET("/preferences", NotificationHttp.ViewPreference)
+			notification.PUT("/preferences", NotificationHttp.UpdatePreference)
+			notification.GET("/preferences/mutes", NotificationHttp.ViewAllMute)
+			notification.POST("/preferences/mutes", NotificationHttp.CreateMute)
+			notification.DELETE("/preferences/mutes/:id", NotificationHttp.DeleteMute)
 		}
 	}
 }
@@ -0,0 +1,25 @@
+package wizards
+
+import "context"
+
+func RegisterEventSubscribers() {
+	PostEvents.Register(EventBus)
+	CommentsEvents.Register(EventBus)
+	NotificationEvents.Register(EventBus)
+	RealtimeEvents.Register(EventBus)
+	WebhookEvents.Register(EventBus)
+}
+
+func StartBackgroundWorkers(ctx context.Context) {
+	RegisterEventSubscribers()
+
+	go OutboxRelay.Start(ctx)
+	go RealtimeHub.Run(ctx)
+	go NotificationFanoutWorker.Start(ctx)
+	go NotificationPushWorker.Start(ctx)
+	go NotificationRetention.Start(ctx)
+	go NotificationDigestWorker.Start(ctx)
+	go WebhookDeliveryWorker.Start(ctx)
+	go ExploreWorker.Start(ctx)
+	go UploadCleanupWorker.Start(ctx)
+}